@@ -0,0 +1,299 @@
+// Package api serves changelogs over HTTP
+//
+// A single changelog file is served at the root:
+//
+//	GET /versions                 all the versions
+//	GET /versions?from=X&to=Y     versions newer than X up to Y
+//	GET /versions/latest          newest released version
+//	GET /versions/{name}          a version
+//
+// For a directory, the changelogs are listed at /changelogs and each
+// one is served under /changelogs/{name}. The responses are JSON,
+// markdown or HTML according to the Accept header.
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/render"
+	"github.com/rcmachado/changelog/workspace"
+)
+
+// Media types and the format used to render them, in the order they
+// are preferred when the client accepts any of them
+var mediaTypes = []struct {
+	mediaType string
+	format    string
+}{
+	{"application/json", "json"},
+	{"text/markdown", "markdown"},
+	{"text/html", "html"},
+}
+
+// requestError is an error caused by the request, replied with status
+type requestError struct {
+	status  int
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+// Handler serves a changelog file or the changelogs of a directory
+type Handler struct {
+	path string
+	dir  bool
+
+	mu      sync.Mutex
+	sources map[string]*source // By path, kept between requests
+}
+
+// NewHandler creates the handler for path, a changelog file or a
+// directory. The changelogs in a directory are the CHANGELOG.md file
+// at its root and the ones of its subdirectories.
+func NewHandler(path string) (*Handler, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		path:    path,
+		dir:     info.IsDir(),
+		sources: make(map[string]*source),
+	}, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	if !h.dir {
+		h.serveChangelog(w, r, h.fileSource(h.path), parts)
+		return
+	}
+
+	if parts[0] != "changelogs" {
+		http.NotFound(w, r)
+		return
+	}
+
+	pkgs, err := h.packages()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if len(parts) == 1 {
+		h.serveList(w, r, pkgs)
+		return
+	}
+
+	for _, pkg := range pkgs {
+		if pkg.Name == parts[1] {
+			h.serveChangelog(w, r, h.fileSource(pkg.Path), parts[2:])
+			return
+		}
+	}
+	http.Error(w, fmt.Sprintf("Unknown changelog: '%s'", parts[1]), http.StatusNotFound)
+}
+
+// fileSource returns the source of the file. The sources are kept
+// between requests, so unchanged files aren't parsed again.
+func (h *Handler) fileSource(path string) *source {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.sources[path]
+	if !ok {
+		s = newSource(path)
+		h.sources[path] = s
+	}
+	return s
+}
+
+// packages discovers the changelogs of the directory on each request,
+// as files may be added or removed while serving
+func (h *Handler) packages() ([]workspace.Package, error) {
+	pkgs, err := workspace.Discover([]string{
+		filepath.Join(h.path, "CHANGELOG.md"),
+		filepath.Join(h.path, "*", "CHANGELOG.md"),
+	}, "")
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		return pkgs[i].Name < pkgs[j].Name
+	})
+	return pkgs, nil
+}
+
+func (h *Handler) serveList(w http.ResponseWriter, r *http.Request, pkgs []workspace.Package) {
+	type changelog struct {
+		Name string `json:"name"`
+	}
+
+	list := make([]changelog, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		list = append(list, changelog{Name: pkg.Name})
+	}
+
+	body, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeBody(w, r, "application/json", append(body, '\n'))
+}
+
+// serveChangelog handles the routes of a changelog, parts being the
+// path after its prefix
+func (h *Handler) serveChangelog(w http.ResponseWriter, r *http.Request, src *source, parts []string) {
+	if len(parts) == 0 || parts[0] != "versions" || len(parts) > 2 {
+		http.NotFound(w, r)
+		return
+	}
+
+	mediaType, format := negotiate(r.Header.Get("Accept"))
+	if format == "" {
+		http.Error(w, "Not acceptable, use one of: application/json, text/markdown, text/html", http.StatusNotAcceptable)
+		return
+	}
+
+	renderer, err := render.New(format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = src.with(r.Context(), func(changelog *chg.Changelog) error {
+		if len(parts) == 2 {
+			v := changelog.Version(resolve(changelog, parts[1]))
+			if v == nil {
+				return &requestError{http.StatusNotFound, fmt.Sprintf("Unknown version: '%s'", parts[1])}
+			}
+			return renderer.Version(&buf, changelog, v)
+		}
+
+		query := r.URL.Query()
+		versions, err := changelog.Between(resolve(changelog, query.Get("from")), resolve(changelog, query.Get("to")))
+		if err != nil {
+			return &requestError{http.StatusBadRequest, err.Error()}
+		}
+		return renderer.Versions(&buf, changelog, versions)
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if e, ok := err.(*requestError); ok {
+			status = e.status
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	writeBody(w, r, mediaType, buf.Bytes())
+}
+
+// resolve translates "latest" into the newest released version
+func resolve(c *chg.Changelog, version string) string {
+	if strings.ToLower(version) != "latest" {
+		return version
+	}
+	if latest := c.Latest(); latest != nil {
+		return latest.Name
+	}
+	return version
+}
+
+// negotiate picks the media type and format for the Accept header.
+// The format is empty when none of the accepted media types is available.
+func negotiate(accept string) (string, string) {
+	if strings.TrimSpace(accept) == "" {
+		return mediaTypes[0].mediaType, mediaTypes[0].format
+	}
+
+	type accepted struct {
+		mediaType string
+		q         float64
+	}
+	var list []accepted
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		a := accepted{mediaType: strings.ToLower(strings.TrimSpace(params[0])), q: 1}
+		for _, param := range params[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 && kv[0] == "q" {
+				if q, err := strconv.ParseFloat(kv[1], 64); err == nil {
+					a.q = q
+				}
+			}
+		}
+		if a.q > 0 {
+			list = append(list, a)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].q > list[j].q
+	})
+
+	for _, a := range list {
+		for _, mt := range mediaTypes {
+			if a.mediaType == mt.mediaType || a.mediaType == "*/*" ||
+				(strings.HasSuffix(a.mediaType, "/*") && strings.HasPrefix(mt.mediaType, strings.TrimSuffix(a.mediaType, "*"))) {
+				return mt.mediaType, mt.format
+			}
+		}
+	}
+	return "", ""
+}
+
+// writeBody writes the response with an ETag computed from the body,
+// replying 304 Not Modified when the client already has it
+func writeBody(w http.ResponseWriter, r *http.Request, mediaType string, body []byte) {
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(append([]byte(mediaType+"\n"), body...)))
+
+	header := w.Header()
+	header.Set("ETag", etag)
+	header.Set("Vary", "Accept")
+	header.Set("Cache-Control", "no-cache")
+
+	if matchETag(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	header.Set("Content-Type", mediaType+"; charset=utf-8")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+}
+
+func matchETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
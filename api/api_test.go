@@ -0,0 +1,251 @@
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testChangelog = `# Changelog
+
+## [Unreleased]
+### Added
+- Item 4
+
+## [2.0.0] - 2020-02-01
+### Fixed
+- Item 3
+
+## [1.0.0] - 2020-01-01
+### Added
+- Item 1
+- Item 2
+
+[Unreleased]: https://example.com/compare/2.0.0...HEAD
+[2.0.0]: https://example.com/compare/1.0.0...2.0.0
+`
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "changelog-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func get(h http.Handler, path string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range headers {
+		r.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func newTestHandler(t *testing.T) (*Handler, string) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "CHANGELOG.md")
+	writeFile(t, path, testChangelog)
+
+	h, err := NewHandler(path)
+	assert.Nil(t, err)
+	return h, dir
+}
+
+func TestNewHandlerMissingPath(t *testing.T) {
+	_, err := NewHandler("testdata/missing.md")
+	assert.NotNil(t, err)
+}
+
+func TestHandlerVersion(t *testing.T) {
+	h, dir := newTestHandler(t)
+	defer os.RemoveAll(dir)
+
+	w := get(h, "/versions/2.0.0", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{
+  "name": "2.0.0",
+  "date": "2020-02-01",
+  "link": "https://example.com/compare/1.0.0...2.0.0",
+  "yanked": false,
+  "changes": [
+    {
+      "type": "Fixed",
+      "items": [
+        "Item 3"
+      ]
+    }
+  ]
+}
+`, w.Body.String())
+
+	w = get(h, "/versions/latest", map[string]string{"Accept": "text/markdown"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/markdown; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `## [2.0.0] - 2020-02-01
+### Fixed
+- Item 3
+
+[2.0.0]: https://example.com/compare/1.0.0...2.0.0
+`, w.Body.String())
+
+	w = get(h, "/versions/3.0.0", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "Unknown version: '3.0.0'\n", w.Body.String())
+}
+
+func TestHandlerVersions(t *testing.T) {
+	h, dir := newTestHandler(t)
+	defer os.RemoveAll(dir)
+
+	w := get(h, "/versions?from=1.0.0&to=latest", map[string]string{"Accept": "text/html"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `<h2><a href="https://example.com/compare/1.0.0...2.0.0">2.0.0</a> - 2020-02-01</h2>
+
+<h3>Fixed</h3>
+
+<ul>
+<li>Item 3</li>
+</ul>
+`, w.Body.String())
+
+	w = get(h, "/versions?from=2.0.0", map[string]string{"Accept": "text/markdown"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `## [Unreleased]
+### Added
+- Item 4
+
+[Unreleased]: https://example.com/compare/2.0.0...HEAD
+`, w.Body.String())
+
+	w = get(h, "/versions?from=3.0.0", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestHandlerNotFound(t *testing.T) {
+	h, dir := newTestHandler(t)
+	defer os.RemoveAll(dir)
+
+	for _, path := range []string{"/", "/changelogs", "/versions/1.0.0/items"} {
+		w := get(h, path, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
+
+func TestHandlerMethodNotAllowed(t *testing.T) {
+	h, dir := newTestHandler(t)
+	defer os.RemoveAll(dir)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/versions", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, HEAD", w.Header().Get("Allow"))
+}
+
+func TestHandlerNotAcceptable(t *testing.T) {
+	h, dir := newTestHandler(t)
+	defer os.RemoveAll(dir)
+
+	w := get(h, "/versions", map[string]string{"Accept": "image/png"})
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+}
+
+func TestHandlerETag(t *testing.T) {
+	h, dir := newTestHandler(t)
+	defer os.RemoveAll(dir)
+
+	w := get(h, "/versions/1.0.0", nil)
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	w = get(h, "/versions/1.0.0", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = get(h, "/versions/1.0.0", map[string]string{"If-None-Match": etag, "Accept": "text/markdown"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+}
+
+func TestHandlerReload(t *testing.T) {
+	h, dir := newTestHandler(t)
+	defer os.RemoveAll(dir)
+
+	w := get(h, "/versions/latest", nil)
+	assert.Contains(t, w.Body.String(), `"name": "2.0.0"`)
+
+	changelog := "# Changelog\n\n## 3.0.0 - 2020-03-01\n### Removed\n- Item 1\n" + testChangelog[len("# Changelog\n"):]
+	writeFile(t, filepath.Join(dir, "CHANGELOG.md"), changelog)
+
+	w = get(h, "/versions/latest", nil)
+	assert.Contains(t, w.Body.String(), `"name": "3.0.0"`)
+}
+
+func TestHandlerDirectory(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, filepath.Join(dir, "web", "CHANGELOG.md"), testChangelog)
+	writeFile(t, filepath.Join(dir, "api", "CHANGELOG.md"), "# Changelog\n\n## 0.1.0 - 2020-01-01\n### Added\n- API\n")
+
+	h, err := NewHandler(dir)
+	assert.Nil(t, err)
+
+	w := get(h, "/changelogs", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `[
+  {
+    "name": "api"
+  },
+  {
+    "name": "web"
+  }
+]
+`, w.Body.String())
+
+	w = get(h, "/changelogs/api/versions/latest", map[string]string{"Accept": "text/markdown"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "## 0.1.0 - 2020-01-01\n### Added\n- API\n", w.Body.String())
+
+	w = get(h, "/changelogs/mobile/versions", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "Unknown changelog: 'mobile'\n", w.Body.String())
+
+	w = get(h, "/versions", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]string{
+		"":                                  "json",
+		"*/*":                               "json",
+		"text/*":                            "markdown",
+		"text/html, application/json;q=0.9": "html",
+		"text/html;q=0.5, text/markdown":    "markdown",
+		"application/json;q=0, text/*;q=1":  "markdown",
+		"application/xml":                   "",
+	}
+
+	for accept, expected := range tests {
+		_, format := negotiate(accept)
+		assert.Equal(t, expected, format, accept)
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rcmachado/changelog/changelog"
+	"github.com/rcmachado/changelog/chg"
+)
+
+// source is a changelog file parsed again whenever it changes on disk
+type source struct {
+	path string
+
+	mu        sync.Mutex
+	modTime   time.Time
+	size      int64
+	changelog *chg.Changelog
+}
+
+func newSource(path string) *source {
+	return &source{path: path}
+}
+
+// with calls fn with the changelog, reading the file again only if its
+// modification time or size changed since the last time. Rendering
+// sorts the sections in place, so fn holds the lock while it runs.
+// When the file can't be parsed, the error is returned and it's read
+// again on the next call.
+func (s *source) with(ctx context.Context, fn func(c *chg.Changelog) error) error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.changelog == nil || !info.ModTime().Equal(s.modTime) || info.Size() != s.size {
+		doc, err := changelog.Load(ctx, s.path)
+		if err != nil {
+			return err
+		}
+
+		s.changelog = doc.Changelog
+		s.modTime = info.ModTime()
+		s.size = info.Size()
+	}
+
+	return fn(s.changelog)
+}
@@ -0,0 +1,181 @@
+// Package changelog is the API to use keepachangelog.com changelogs from
+// Go programs: load them from files or readers, change them, validate,
+// render in the available formats and save them atomically.
+//
+// The commands of the changelog tool are built on it.
+//
+// The change types and the markdown style are global, like in the chg
+// package: configure them with chg.SetChangeTypes and chg.SetStyle, or
+// use WithStyle for a single Render or Save.
+package changelog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/parser"
+)
+
+// Document is a changelog. The embedded chg.Changelog can be changed
+// directly for edits not covered by the methods.
+type Document struct {
+	*chg.Changelog
+	// Path is the file the document was loaded from, written by Save
+	Path string
+	// Warnings is the content of the input ignored by the parser, like
+	// the items under unknown sections. See Rewritable.
+	Warnings []parser.Warning
+}
+
+// New returns a changelog with the default preamble and an Unreleased
+// version. It accepts WithCompareURL, the link of Unreleased.
+func New(opts ...Option) *Document {
+	o := newOptions(opts)
+	return &Document{Changelog: chg.NewEmptyChangelog(o.compareURL)}
+}
+
+// Read parses the changelog from r. With WithStrict, it's also
+// validated, returning the document and a *ValidationError when there
+// are problems.
+func Read(ctx context.Context, r io.Reader, opts ...Option) (*Document, error) {
+	c, m, err := parser.ParseWithSourceMap(&contextReader{ctx: ctx, r: r})
+	if err == nil && len(m.Errors) > 0 {
+		err = m.Errors[0]
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading changelog: %w", err)
+	}
+
+	doc := &Document{Changelog: c, Warnings: m.Warnings}
+	if newOptions(opts).strict {
+		return doc, doc.Validate()
+	}
+	return doc, nil
+}
+
+// Load reads the changelog file at path. It accepts the same options
+// as Read.
+func Load(ctx context.Context, path string, opts ...Option) (*Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	doc, err := Read(ctx, f, opts...)
+	if doc != nil {
+		doc.Path = path
+	}
+	return doc, err
+}
+
+// Rewritable returns an *IgnoredContentError when the parser ignored
+// part of the input, which writing the document back would lose
+func (d *Document) Rewritable() error {
+	if len(d.Warnings) > 0 {
+		return &IgnoredContentError{Warnings: d.Warnings}
+	}
+	return nil
+}
+
+// Save writes the changelog in markdown to its Path. See SaveAs.
+func (d *Document) Save(ctx context.Context, opts ...Option) error {
+	if d.Path == "" {
+		return ErrNoPath
+	}
+	return d.SaveAs(ctx, d.Path, opts...)
+}
+
+// SaveAs writes the changelog in markdown to path, which becomes the
+// Path of the document. The content is written to a temporary file
+// that replaces path, so readers never see a partial changelog.
+//
+// It accepts WithStyle and WithFileMode; the mode of an existing file
+// is kept by default. Documents with content ignored by the parser
+// aren't written, see Rewritable.
+func (d *Document) SaveAs(ctx context.Context, path string, opts ...Option) error {
+	o := newOptions(opts)
+
+	if err := d.Rewritable(); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := d.Render(ctx, &buf, opts...); err != nil {
+		return err
+	}
+
+	mode := o.fileMode
+	if info, err := os.Stat(path); err == nil && mode == 0 {
+		mode = info.Mode().Perm()
+	}
+	if mode == 0 {
+		mode = 0644
+	}
+
+	if err := writeAtomic(ctx, path, buf.Bytes(), mode); err != nil {
+		return err
+	}
+	d.Path = path
+	return nil
+}
+
+// Bytes returns the changelog in markdown, using the configured style
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	d.Changelog.Render(&buf)
+	return buf.Bytes()
+}
+
+// writeAtomic writes the content to a temporary file in the directory
+// of path and renames it to path
+func writeAtomic(ctx context.Context, path string, content []byte, mode os.FileMode) error {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+base+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), mode)
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// contextReader stops reading when the context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,384 @@
+package changelog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/stretchr/testify/assert"
+)
+
+const testChangelog = `# Changelog
+
+## [Unreleased]
+### Added
+- Item 2
+
+## [1.0.0] - 2020-01-01
+### Added
+- Item 1
+
+[Unreleased]: https://example.com/compare/1.0.0...HEAD
+[1.0.0]: https://example.com/releases/tag/1.0.0
+`
+
+func readTest(t *testing.T, opts ...Option) *Document {
+	doc, err := Read(context.Background(), strings.NewReader(testChangelog), opts...)
+	assert.Nil(t, err)
+	return doc
+}
+
+func TestRead(t *testing.T) {
+	doc := readTest(t)
+
+	assert.Len(t, doc.Versions, 2)
+	assert.Equal(t, testChangelog, string(doc.Bytes()))
+}
+
+func TestReadCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Read(ctx, strings.NewReader(testChangelog))
+
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestReadStrict(t *testing.T) {
+	input := "# Changelog\n\n## 1.0.0\n### Added\n- Item\n"
+
+	doc, err := Read(context.Background(), strings.NewReader(input), WithStrict())
+
+	var invalid *ValidationError
+	assert.True(t, errors.As(err, &invalid))
+	assert.NotEmpty(t, invalid.Problems)
+	assert.NotNil(t, doc)
+}
+
+func TestLoad(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "changelog")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "CHANGELOG.md")
+	ioutil.WriteFile(path, []byte(testChangelog), 0644)
+
+	doc, err := Load(context.Background(), path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, path, doc.Path)
+
+	_, err = Load(context.Background(), filepath.Join(dir, "missing.md"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestNew(t *testing.T) {
+	doc := New(WithCompareURL("https://example.com/compare/0.0.0...HEAD"))
+
+	assert.NotNil(t, doc.Version("Unreleased"))
+	assert.Contains(t, string(doc.Bytes()), "[Unreleased]: https://example.com/compare/0.0.0...HEAD")
+}
+
+func TestAdd(t *testing.T) {
+	doc := readTest(t)
+
+	item, err := doc.Add("fixed", "Crash\n\n- On empty files", WithIssues("12", "org/repo#3"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Crash (#12, org/repo#3)", item.Description)
+	assert.Len(t, item.Children, 1)
+	assert.Equal(t, item, doc.Version("Unreleased").Change(chg.Fixed).Items[0])
+}
+
+func TestAddVersion(t *testing.T) {
+	doc := readTest(t)
+
+	_, err := doc.Add("added", "Item 0", WithVersion("1.0.0"))
+	assert.Nil(t, err)
+	assert.Len(t, doc.Version("1.0.0").Change(chg.Added).Items, 2)
+
+	_, err = doc.Add("added", "Item", WithVersion("2.0.0"))
+	assert.True(t, errors.Is(err, ErrUnknownVersion))
+}
+
+func TestAddCreatesUnreleased(t *testing.T) {
+	doc := &Document{Changelog: &chg.Changelog{Title: "Changelog"}}
+
+	_, err := doc.Add("added", "Item")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Unreleased", doc.Versions[0].Name)
+}
+
+func TestAddErrors(t *testing.T) {
+	doc := readTest(t)
+
+	_, err := doc.Add("improved", "Item")
+	assert.True(t, errors.Is(err, ErrUnknownChangeType))
+
+	_, err = doc.Add("added", "  ")
+	assert.True(t, errors.Is(err, ErrEmptyItem))
+}
+
+func TestRelease(t *testing.T) {
+	doc := readTest(t)
+
+	v, err := doc.Release("1.1.0", WithDate("31/01/2024"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "2024-01-31", v.Date)
+	assert.Equal(t, "Unreleased", doc.Versions[0].Name)
+	assert.Equal(t, "1.1.0", doc.Versions[1].Name)
+}
+
+func TestReleaseErrors(t *testing.T) {
+	doc := readTest(t)
+
+	_, err := doc.Release("1.0.0")
+	assert.True(t, errors.Is(err, ErrVersionExists))
+
+	_, err = doc.Release("1.1.0", WithDate("someday"))
+	assert.True(t, errors.Is(err, ErrInvalidDate))
+
+	doc.Versions = doc.Versions[1:]
+	_, err = doc.Release("1.1.0")
+	assert.True(t, errors.Is(err, ErrUnknownVersion))
+
+	doc = readTest(t)
+	doc.Version("Unreleased").Link = "https://example.com/commits/main"
+	doc.Version("1.0.0").Link = ""
+	_, err = doc.Release("1.1.0")
+	assert.EqualError(t, err, "Could not infer the compare link from 'https://example.com/commits/main'")
+}
+
+func TestReleasePromote(t *testing.T) {
+	doc := readTest(t)
+	doc.Release("1.1.0-rc.1")
+	doc.Add("fixed", "Bug")
+
+	v, err := doc.Release("1.1.0", WithPromote(false))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Item 2"}, itemTexts(v.Change(chg.Added)))
+	assert.Equal(t, []string{"Bug"}, itemTexts(v.Change(chg.Fixed)))
+	assert.Equal(t, "https://example.com/compare/1.0.0...1.1.0", v.Link)
+	assert.Nil(t, doc.Version("1.1.0-rc.1"))
+
+	_, err = doc.Release("1.2.0-rc.1", WithPromote(true))
+	assert.True(t, errors.Is(err, ErrNotFinalVersion))
+	assert.Nil(t, doc.Version("1.2.0-rc.1"))
+}
+
+func itemTexts(cl *chg.ChangeList) []string {
+	var texts []string
+	for _, item := range cl.Items {
+		texts = append(texts, item.Text())
+	}
+	return texts
+}
+
+func TestUnrelease(t *testing.T) {
+	doc := readTest(t)
+	doc.Release("1.1.0")
+
+	v, err := doc.Unrelease("1.1.0")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Unreleased", v.Name)
+	assert.Equal(t, testChangelog, string(doc.Bytes()))
+
+	_, err = doc.Unrelease("2.0.0")
+	assert.True(t, errors.Is(err, ErrUnknownVersion))
+}
+
+func TestRenameVersion(t *testing.T) {
+	doc := readTest(t)
+
+	v, err := doc.RenameVersion("1.0.0", "1.0.0-rc.1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0-rc.1", v.Name)
+	assert.Equal(t, "https://example.com/compare/1.0.0-rc.1...HEAD", doc.Version("Unreleased").Link)
+
+	_, err = doc.RenameVersion("1.0.0", "1.0.1")
+	assert.True(t, errors.Is(err, ErrUnknownVersion))
+
+	_, err = doc.RenameVersion("1.0.0-rc.1", "unreleased")
+	assert.True(t, errors.Is(err, ErrVersionExists))
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, readTest(t).Validate())
+
+	doc := readTest(t)
+	doc.Version("1.0.0").Date = ""
+
+	err := doc.Validate()
+
+	var invalid *ValidationError
+	assert.True(t, errors.As(err, &invalid))
+	assert.Contains(t, err.Error(), "found 1 problem(s)")
+}
+
+func TestRender(t *testing.T) {
+	doc := readTest(t)
+
+	var buf bytes.Buffer
+	err := doc.Render(context.Background(), &buf, WithFormat("json"), WithVersions("1.0.0"))
+
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), `"1.0.0"`)
+	assert.NotContains(t, buf.String(), `"Unreleased"`)
+
+	err = doc.Render(context.Background(), &buf, WithVersions("2.0.0"))
+	assert.True(t, errors.Is(err, ErrUnknownVersion))
+}
+
+func TestRenderStyle(t *testing.T) {
+	doc := readTest(t)
+
+	var buf bytes.Buffer
+	err := doc.Render(context.Background(), &buf, WithStyle(chg.Style{Bullet: "*"}))
+
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), "* Item 1")
+	assert.Contains(t, string(doc.Bytes()), "- Item 1")
+
+	err = doc.Render(context.Background(), &buf, WithStyle(chg.Style{Bullet: "x"}))
+	var styleErr *StyleError
+	assert.True(t, errors.As(err, &styleErr))
+}
+
+func TestRenderStyleConcurrent(t *testing.T) {
+	doc := readTest(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			var buf bytes.Buffer
+			assert.Nil(t, doc.Render(context.Background(), &buf, WithStyle(chg.Style{Bullet: "*"})))
+			assert.Contains(t, buf.String(), "* Item 1")
+		}()
+		go func() {
+			defer wg.Done()
+			assert.Contains(t, string(doc.Bytes()), "- Item 1")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRenderVersion(t *testing.T) {
+	doc := readTest(t)
+
+	var buf bytes.Buffer
+	err := doc.RenderVersion(context.Background(), &buf, "1.0.0", WithFormat("text"), WithWidth(40))
+
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), "Item 1")
+	assert.NotContains(t, buf.String(), "Item 2")
+}
+
+func TestRenderCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := readTest(t).Render(ctx, ioutil.Discard)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestSave(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "changelog")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "CHANGELOG.md")
+	ioutil.WriteFile(path, []byte(testChangelog), 0600)
+
+	doc, _ := Load(context.Background(), path)
+	doc.Add("fixed", "Item 3")
+	err := doc.Save(context.Background())
+
+	assert.Nil(t, err)
+	content, _ := ioutil.ReadFile(path)
+	assert.Contains(t, string(content), "### Fixed\n- Item 3")
+	info, _ := os.Stat(path)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, 1, "the temporary file is removed")
+}
+
+func TestSaveAs(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "changelog")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "CHANGELOG.md")
+
+	doc := readTest(t)
+	assert.Equal(t, ErrNoPath, doc.Save(context.Background()))
+
+	err := doc.SaveAs(context.Background(), path, WithFileMode(0640))
+
+	assert.Nil(t, err)
+	assert.Equal(t, path, doc.Path)
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, testChangelog, string(content))
+	info, _ := os.Stat(path)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+}
+
+func TestSaveIgnoredContent(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "changelog")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "CHANGELOG.md")
+
+	input := strings.Replace(testChangelog, "### Added\n- Item 2", "### Performance\n- Item 2", 1)
+	doc, err := Read(context.Background(), strings.NewReader(input))
+	assert.Nil(t, err)
+
+	err = doc.SaveAs(context.Background(), path)
+
+	var ignored *IgnoredContentError
+	assert.True(t, errors.As(err, &ignored))
+	assert.EqualError(t, err, "content would be lost: line 4: unknown change type 'Performance', its items are ignored")
+	assert.NoFileExists(t, path)
+
+	var invalid *ValidationError
+	assert.True(t, errors.As(doc.Validate(), &invalid))
+	assert.Equal(t, "line 4: unknown change type 'Performance', its items are ignored", invalid.Problems[0].String())
+}
+
+func TestSaveCanceled(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "changelog")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "CHANGELOG.md")
+	ioutil.WriteFile(path, []byte(testChangelog), 0644)
+
+	doc, _ := Load(context.Background(), path)
+	doc.Add("fixed", "Item 3")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := doc.Save(ctx)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	content, _ := ioutil.ReadFile(path)
+	assert.Equal(t, testChangelog, string(content))
+}
+
+func TestWithIssueRefs(t *testing.T) {
+	tests := map[string][]string{
+		"Item":                            nil,
+		"Item (#1)":                       {"1"},
+		"Item (#1, GH-2, https://x.io/3)": {" 1 ", "GH-2", "", "https://x.io/3"},
+	}
+
+	for expected, issues := range tests {
+		assert.Equal(t, expected, withIssueRefs("Item", issues))
+	}
+}
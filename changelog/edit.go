@@ -0,0 +1,158 @@
+package changelog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/lint"
+	"github.com/rcmachado/changelog/parser"
+)
+
+// Add adds an item to Unreleased, created when missing, and returns
+// it. changeType is the name, heading or alias of a change type. The
+// text is markdown: the paragraphs, code blocks and lists after the
+// first paragraph become the body and children of the item.
+//
+// It accepts WithVersion and WithIssues.
+func (d *Document) Add(changeType, text string, opts ...Option) (*chg.Item, error) {
+	o := newOptions(opts)
+
+	ct := chg.ChangeTypeFromString(changeType)
+	if ct == chg.Unknown {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownChangeType, changeType)
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyItem
+	}
+
+	name := o.version
+	if name == "" {
+		name = "Unreleased"
+	}
+	v := d.Version(name)
+	if v == nil {
+		if !strings.EqualFold(name, "Unreleased") {
+			return nil, fmt.Errorf("%w: '%s'", ErrUnknownVersion, name)
+		}
+		v = &chg.Version{Name: "Unreleased"}
+		d.Versions = append([]*chg.Version{v}, d.Versions...)
+	}
+
+	parsed := parser.ParseItem(text)
+	item := v.AddItem(ct, withIssueRefs(parsed.Description, o.issues))
+	item.Body, item.Children = parsed.Body, parsed.Children
+	return item, nil
+}
+
+// Release turns Unreleased into version and adds a new empty
+// Unreleased. The compare links are updated from the previous ones.
+//
+// It accepts WithDate (today by default), WithCompareURL, required when
+// the link can't be inferred, WithTagPrefix and WithPromote.
+func (d *Document) Release(version string, opts ...Option) (*chg.Version, error) {
+	o := newOptions(opts)
+
+	if d.Version("Unreleased") == nil {
+		return nil, fmt.Errorf("%w: 'Unreleased'", ErrUnknownVersion)
+	}
+	if d.Version(version) != nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrVersionExists, version)
+	}
+	if o.promote {
+		if semver, err := chg.ParseSemVer(version); err != nil || semver.IsPrerelease() {
+			return nil, fmt.Errorf("%w: '%s'", ErrNotFinalVersion, version)
+		}
+	}
+
+	date := time.Now().Format(chg.ISODate)
+	if o.date != "" {
+		t, err := chg.ParseDate(o.date)
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidDate, o.date)
+		}
+		date = t.Format(chg.ISODate)
+	}
+
+	released, err := d.ReleaseWithTagPrefix(chg.Version{Name: version, Date: date, Link: o.compareURL}, o.tagPrefix)
+	if err != nil || !o.promote {
+		return released, err
+	}
+	if _, err := d.PromoteWithTagPrefix(version, o.keep, o.tagPrefix); err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// Unrelease reverts Release, merging the latest release back into
+// Unreleased and restoring its compare link. version, when informed,
+// has to be the latest release.
+//
+// It accepts WithTagPrefix.
+func (d *Document) Unrelease(version string, opts ...Option) (*chg.Version, error) {
+	o := newOptions(opts)
+
+	if version != "" && d.Version(version) == nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownVersion, version)
+	}
+	return d.UnreleaseWithTagPrefix(version, o.tagPrefix)
+}
+
+// RenameVersion renames a released version and updates the compare
+// links referencing it. Versions following semantic versioning have to
+// stay in order.
+//
+// It accepts WithTagPrefix.
+func (d *Document) RenameVersion(oldName, newName string, opts ...Option) (*chg.Version, error) {
+	o := newOptions(opts)
+
+	v := d.Version(oldName)
+	if v == nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownVersion, oldName)
+	}
+	if other := d.Version(newName); other != nil && other != v {
+		return nil, fmt.Errorf("%w: '%s'", ErrVersionExists, newName)
+	}
+	return d.RenameVersionWithTagPrefix(oldName, newName, o.tagPrefix)
+}
+
+// Validate checks the changelog against keepachangelog.com conventions,
+// returning a *ValidationError with the problems found. The content
+// ignored by the parser is reported first.
+func (d *Document) Validate() error {
+	var problems []lint.Problem
+	for _, w := range d.Warnings {
+		problems = append(problems, lint.Problem{Message: w.String()})
+	}
+	problems = append(problems, lint.Check(d.Changelog)...)
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// withIssueRefs appends the issue references to the message, eg.
+// "Fix crash (#12, org/repo#3)". Plain numbers are prefixed with "#".
+func withIssueRefs(message string, issues []string) string {
+	var refs []string
+	for _, issue := range issues {
+		issue = strings.TrimSpace(issue)
+		if issue == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(issue); err == nil {
+			issue = "#" + issue
+		}
+		refs = append(refs, issue)
+	}
+
+	if len(refs) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (%s)", message, strings.Join(refs, ", "))
+}
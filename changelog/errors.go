@@ -0,0 +1,65 @@
+package changelog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rcmachado/changelog/lint"
+	"github.com/rcmachado/changelog/parser"
+)
+
+// Errors returned by the package, usually wrapped with details. Check
+// them with errors.Is.
+var (
+	ErrUnknownVersion    = errors.New("unknown version")
+	ErrUnknownChangeType = errors.New("unknown change type")
+	ErrVersionExists     = errors.New("version already exists")
+	ErrInvalidDate       = errors.New("invalid date")
+	ErrEmptyItem         = errors.New("empty item")
+	ErrNoPath            = errors.New("the document has no path")
+	ErrNotFinalVersion   = errors.New("not a final version")
+)
+
+// StyleError is returned when the style informed with WithStyle is
+// invalid
+type StyleError struct {
+	Err error
+}
+
+func (e *StyleError) Error() string {
+	return "invalid style: " + e.Err.Error()
+}
+
+// Unwrap returns the reason the style is invalid
+func (e *StyleError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned when the changelog doesn't follow the
+// keepachangelog.com conventions
+type ValidationError struct {
+	Problems []lint.Problem
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for idx, p := range e.Problems {
+		messages[idx] = p.String()
+	}
+	return fmt.Sprintf("found %d problem(s): %s", len(e.Problems), strings.Join(messages, "; "))
+}
+
+// IgnoredContentError is returned when writing a document with content
+// ignored by the parser, as it would be lost
+type IgnoredContentError struct {
+	Warnings []parser.Warning
+}
+
+func (e *IgnoredContentError) Error() string {
+	messages := make([]string, len(e.Warnings))
+	for idx, w := range e.Warnings {
+		messages[idx] = w.String()
+	}
+	return fmt.Sprintf("content would be lost: %s", strings.Join(messages, "; "))
+}
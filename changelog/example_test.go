@@ -0,0 +1,84 @@
+package changelog_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/rcmachado/changelog/changelog"
+)
+
+func Example() {
+	input := `# Changelog
+
+## [Unreleased]
+### Added
+- Search command
+
+## [1.0.0] - 2024-01-02
+### Added
+- First release
+
+[Unreleased]: https://example.com/compare/1.0.0...HEAD
+[1.0.0]: https://example.com/releases/tag/1.0.0
+`
+	ctx := context.Background()
+
+	doc, err := changelog.Read(ctx, strings.NewReader(input), changelog.WithStrict())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err := doc.Add("fixed", "Crash on empty files", changelog.WithIssues("42")); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := doc.Release("1.1.0", changelog.WithDate("2024-02-01")); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := doc.RenderVersion(ctx, os.Stdout, "1.1.0", changelog.WithFormat("text")); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// 1.1.0 - 2024-02-01
+	// ------------------
+	//
+	// Added:
+	//   - Search command
+	//
+	// Fixed:
+	//   - Crash on empty files (#42)
+}
+
+func ExampleDocument_Save() {
+	ctx := context.Background()
+
+	doc, err := changelog.Load(ctx, "CHANGELOG.md")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err := doc.Add("added", "Library API"); err != nil {
+		log.Fatal(err)
+	}
+
+	// The file is replaced atomically, keeping its permissions
+	if err := doc.Save(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleValidationError() {
+	input := "# Changelog\n\n## 1.0.0\n### Added\n- First release\n"
+
+	_, err := changelog.Read(context.Background(), strings.NewReader(input), changelog.WithStrict())
+
+	var invalid *changelog.ValidationError
+	if errors.As(err, &invalid) {
+		fmt.Println(len(invalid.Problems) > 0)
+	}
+	// Output: true
+}
@@ -0,0 +1,142 @@
+package changelog
+
+import (
+	"os"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// Option changes the behavior of the functions and methods of the
+// package. Each one documents the options it accepts; the others are
+// ignored.
+type Option func(*options)
+
+type options struct {
+	strict     bool
+	compareURL string
+	tagPrefix  string
+	date       string
+	version    string
+	issues     []string
+	format     string
+	versions   []string
+	style      *chg.Style
+	width      int
+	color      bool
+	fileMode   os.FileMode
+	promote    bool
+	keep       bool
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{format: "markdown"}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithStrict validates the changelog after reading it
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithCompareURL sets the compare link of Unreleased in New, and of the
+// released version in Release. In Release, "<prev>" and "<next>" are
+// replaced by the new tag and HEAD.
+func WithCompareURL(url string) Option {
+	return func(o *options) {
+		o.compareURL = url
+	}
+}
+
+// WithTagPrefix informs the prefix of the tags in the compare links,
+// eg. "pkg-a@" for tags like "pkg-a@1.2.0", when updating them
+func WithTagPrefix(prefix string) Option {
+	return func(o *options) {
+		o.tagPrefix = prefix
+	}
+}
+
+// WithDate sets the release date, in one of the formats recognized by
+// chg.ParseDate. Releases are dated today by default.
+func WithDate(date string) Option {
+	return func(o *options) {
+		o.date = date
+	}
+}
+
+// WithVersion adds the item to the version instead of Unreleased
+func WithVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+// WithIssues appends issue references to the item, eg. "(#12, org/repo#3)".
+// Plain numbers are prefixed with "#".
+func WithIssues(issues ...string) Option {
+	return func(o *options) {
+		o.issues = append(o.issues, issues...)
+	}
+}
+
+// WithFormat sets the render format, one of render.Formats(). The
+// default is markdown.
+func WithFormat(format string) Option {
+	return func(o *options) {
+		o.format = format
+	}
+}
+
+// WithVersions renders only the versions, in the order informed, or
+// none if the list is empty
+func WithVersions(versions ...string) Option {
+	return func(o *options) {
+		if o.versions == nil {
+			o.versions = []string{}
+		}
+		o.versions = append(o.versions, versions...)
+	}
+}
+
+// WithStyle sets the markdown style while rendering or saving
+func WithStyle(style chg.Style) Option {
+	return func(o *options) {
+		o.style = &style
+	}
+}
+
+// WithWidth sets the columns of the text formats
+func WithWidth(width int) Option {
+	return func(o *options) {
+		o.width = width
+	}
+}
+
+// WithColor enables the colors of the ansi format, which is plain text
+// otherwise. Callers decide when colors are wanted, eg. in terminals.
+func WithColor(color bool) Option {
+	return func(o *options) {
+		o.color = color
+	}
+}
+
+// WithFileMode sets the permissions of the saved file
+func WithFileMode(mode os.FileMode) Option {
+	return func(o *options) {
+		o.fileMode = mode
+	}
+}
+
+// WithPromote gathers the items of the pre-releases of the released
+// version into it, see chg.Changelog.Promote. With keep, the
+// pre-releases are marked as superseded instead of removed.
+func WithPromote(keep bool) Option {
+	return func(o *options) {
+		o.promote = true
+		o.keep = keep
+	}
+}
@@ -0,0 +1,83 @@
+package changelog
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/render"
+)
+
+// Render writes the changelog in markdown, or in the format informed
+// with WithFormat. WithVersions limits the output to some versions.
+//
+// It also accepts WithStyle, and WithWidth and WithColor for the text
+// formats.
+func (d *Document) Render(ctx context.Context, w io.Writer, opts ...Option) error {
+	o := newOptions(opts)
+
+	var versions []*chg.Version
+	for _, name := range o.versions {
+		v := d.Version(name)
+		if v == nil {
+			return fmt.Errorf("%w: '%s'", ErrUnknownVersion, name)
+		}
+		versions = append(versions, v)
+	}
+
+	return d.render(ctx, o, func(r render.Renderer) error {
+		if o.versions != nil {
+			return r.Versions(w, d.Changelog, versions)
+		}
+		return r.Changelog(w, d.Changelog)
+	})
+}
+
+// RenderVersion writes a single version. In JSON, it's an object
+// instead of an array. It accepts the same options as Render.
+func (d *Document) RenderVersion(ctx context.Context, w io.Writer, version string, opts ...Option) error {
+	v := d.Version(version)
+	if v == nil {
+		return fmt.Errorf("%w: '%s'", ErrUnknownVersion, version)
+	}
+
+	return d.render(ctx, newOptions(opts), func(r render.Renderer) error {
+		return r.Version(w, d.Changelog, v)
+	})
+}
+
+// render runs fn with the renderer of the format and the style of the
+// options
+func (d *Document) render(ctx context.Context, o *options, fn func(render.Renderer) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r, err := render.New(o.format)
+	if err != nil {
+		return err
+	}
+	if text, ok := r.(render.Text); ok {
+		text.Width = o.width
+		text.Color = text.Color && o.color
+		r = text
+	}
+
+	if o.style != nil {
+		style, err := o.style.Normalize()
+		if err != nil {
+			return &StyleError{Err: err}
+		}
+		switch styled := r.(type) {
+		case render.Markdown:
+			styled.Style = &style
+			r = styled
+		case render.HTML:
+			styled.Style = &style
+			r = styled
+		}
+	}
+
+	return fn(r)
+}
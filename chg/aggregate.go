@@ -0,0 +1,166 @@
+package chg
+
+import "strings"
+
+// Component is a changelog rolled up into a product changelog
+type Component struct {
+	Name      string
+	Changelog *Changelog
+}
+
+// ProductVersion describes a version of the aggregated changelog
+//
+// Components maps the component names to the version included in
+// this product version. When it's empty, the component versions are
+// picked by date: the ones released after the previous (older) product
+// version up to Date.
+type ProductVersion struct {
+	Name       string
+	Date       string // Release date, in a format accepted by ParseDate
+	Link       string
+	Components map[string]string
+}
+
+type aggregatedItem struct {
+	item       *Item
+	components []string
+}
+
+type aggregatedVersion struct {
+	version *Version
+	items   map[ChangeType][]*aggregatedItem
+}
+
+// Aggregate merges the component changelogs into a single changelog
+// with the product versions (newest first). The items are prefixed
+// with the component name and identical items in the same product
+// version are merged.
+//
+// The Unreleased version holds the Unreleased items of the components
+// and the component versions released after the newest product version
+// that weren't assigned to any product version.
+func Aggregate(components []Component, versions []ProductVersion) *Changelog {
+	assigned := make(map[*Version]bool)
+	for _, pv := range versions {
+		for _, comp := range components {
+			name, ok := pv.Components[comp.Name]
+			if !ok {
+				continue
+			}
+			if v := comp.Changelog.Version(name); v != nil {
+				assigned[v] = true
+			}
+		}
+	}
+
+	unreleased := newAggregatedVersion(Version{Name: "Unreleased"})
+	result := []*aggregatedVersion{unreleased}
+
+	for idx, pv := range versions {
+		av := newAggregatedVersion(Version{Name: pv.Name, Date: pv.Date, Link: pv.Link})
+		result = append(result, av)
+
+		for _, comp := range components {
+			if len(pv.Components) > 0 {
+				if name, ok := pv.Components[comp.Name]; ok {
+					if v := comp.Changelog.Version(name); v != nil {
+						av.add(comp.Name, v)
+					}
+				}
+				continue
+			}
+
+			after := ""
+			if idx+1 < len(versions) {
+				after = NormalizeDate(versions[idx+1].Date)
+			}
+			for _, v := range comp.Changelog.Versions {
+				if v.IsUnreleased() || assigned[v] || v.Date == "" {
+					continue
+				}
+				if date := NormalizeDate(v.Date); date > after && date <= NormalizeDate(pv.Date) {
+					av.add(comp.Name, v)
+					assigned[v] = true
+				}
+			}
+		}
+	}
+
+	newest := ""
+	if len(versions) > 0 {
+		newest = NormalizeDate(versions[0].Date)
+	}
+	for _, comp := range components {
+		for _, v := range comp.Changelog.Versions {
+			if v.IsUnreleased() || (!assigned[v] && (newest == "" || NormalizeDate(v.Date) > newest)) {
+				unreleased.add(comp.Name, v)
+			}
+		}
+	}
+
+	c := NewChangelog()
+	for _, av := range result {
+		c.Versions = append(c.Versions, av.build())
+	}
+
+	// Keep the definitions of the reference-style links used by the
+	// items. When components define the same label, the first one wins.
+	for _, comp := range components {
+		for _, l := range comp.Changelog.UsedLinks(c.Versions) {
+			if c.Link(l.Label) == nil {
+				c.Links = append(c.Links, l)
+			}
+		}
+	}
+	return c
+}
+
+func newAggregatedVersion(v Version) *aggregatedVersion {
+	return &aggregatedVersion{
+		version: &v,
+		items:   make(map[ChangeType][]*aggregatedItem),
+	}
+}
+
+// add includes the items of the component version, merging the
+// duplicated ones
+func (a *aggregatedVersion) add(component string, v *Version) {
+	for _, change := range v.Changes {
+		if len(change.Items) > 0 && a.version.Change(change.Type) == nil {
+			a.version.Changes = append(a.version.Changes, &ChangeList{Type: change.Type})
+		}
+
+	items:
+		for _, item := range change.Items {
+			for _, existing := range a.items[change.Type] {
+				if existing.item.Text() == item.Text() {
+					for _, name := range existing.components {
+						if name == component {
+							continue items
+						}
+					}
+					existing.components = append(existing.components, component)
+					continue items
+				}
+			}
+			a.items[change.Type] = append(a.items[change.Type], &aggregatedItem{
+				item:       item,
+				components: []string{component},
+			})
+		}
+	}
+}
+
+func (a *aggregatedVersion) build() *Version {
+	for _, change := range a.version.Changes {
+		for _, item := range a.items[change.Type] {
+			change.Items = append(change.Items, &Item{
+				Description: strings.Join(item.components, ", ") + ": " + item.item.Description,
+				Body:        item.item.Body,
+				Children:    item.item.Children,
+			})
+		}
+	}
+	a.version.SortChanges()
+	return a.version
+}
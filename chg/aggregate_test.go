@@ -0,0 +1,164 @@
+package chg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAggregateComponents() []Component {
+	api := &Changelog{
+		Versions: []*Version{
+			{
+				Name: "Unreleased",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{{Description: "Pagination"}}},
+				},
+			},
+			{
+				Name: "1.1.0",
+				Date: "2020-07-10",
+				Changes: []*ChangeList{
+					{Type: Fixed, Items: []*Item{{Description: "Timeout"}, {Description: "Update dependencies"}}},
+				},
+			},
+			{
+				Name: "1.0.0",
+				Date: "2020-06-01",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{{Description: "Users endpoint"}}},
+				},
+			},
+		},
+	}
+
+	web := &Changelog{
+		Versions: []*Version{
+			{Name: "Unreleased"},
+			{
+				Name: "0.2.0",
+				Date: "2020-07-15",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{{Description: "Dark mode"}}},
+					{Type: Fixed, Items: []*Item{{Description: "Update dependencies"}}},
+				},
+			},
+			{
+				Name: "0.1.0",
+				Date: "2020-05-20",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{{Description: "Login page"}}},
+				},
+			},
+		},
+	}
+
+	return []Component{
+		{Name: "api", Changelog: api},
+		{Name: "web", Changelog: web},
+	}
+}
+
+func TestAggregateManifest(t *testing.T) {
+	versions := []ProductVersion{
+		{Name: "2.0.0", Date: "2020-08-01", Components: map[string]string{"api": "1.1.0", "web": "0.2.0"}},
+		{Name: "1.0.0", Date: "2020-06-02", Components: map[string]string{"api": "1.0.0", "web": "0.1.0"}},
+	}
+
+	expected := &Changelog{
+		Versions: []*Version{
+			{
+				Name: "Unreleased",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{{Description: "api: Pagination"}}},
+				},
+			},
+			{
+				Name: "2.0.0",
+				Date: "2020-08-01",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{{Description: "web: Dark mode"}}},
+					{Type: Fixed, Items: []*Item{
+						{Description: "api: Timeout"},
+						{Description: "api, web: Update dependencies"},
+					}},
+				},
+			},
+			{
+				Name: "1.0.0",
+				Date: "2020-06-02",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{
+						{Description: "api: Users endpoint"},
+						{Description: "web: Login page"},
+					}},
+				},
+			},
+		},
+	}
+
+	result := Aggregate(newAggregateComponents(), versions)
+	assert.Equal(t, expected, result)
+}
+
+func TestAggregateDateWindow(t *testing.T) {
+	versions := []ProductVersion{
+		{Name: "2.0.0", Date: "2020-07-12"},
+		{Name: "1.0.0", Date: "2020-06-02"},
+	}
+
+	result := Aggregate(newAggregateComponents(), versions)
+	assert.Len(t, result.Versions, 3)
+
+	// web 0.2.0 was released after the newest product version
+	unreleased := result.Version("Unreleased")
+	expectedUnreleased := []*ChangeList{
+		{Type: Added, Items: []*Item{{Description: "api: Pagination"}, {Description: "web: Dark mode"}}},
+		{Type: Fixed, Items: []*Item{{Description: "web: Update dependencies"}}},
+	}
+	assert.Equal(t, expectedUnreleased, unreleased.Changes)
+
+	v200 := result.Version("2.0.0")
+	expected200 := []*ChangeList{
+		{Type: Fixed, Items: []*Item{{Description: "api: Timeout"}, {Description: "api: Update dependencies"}}},
+	}
+	assert.Equal(t, expected200, v200.Changes)
+
+	v100 := result.Version("1.0.0")
+	expected100 := []*ChangeList{
+		{Type: Added, Items: []*Item{{Description: "api: Users endpoint"}, {Description: "web: Login page"}}},
+	}
+	assert.Equal(t, expected100, v100.Changes)
+}
+
+func TestAggregateLinks(t *testing.T) {
+	api := &Changelog{
+		Versions: []*Version{
+			{
+				Name: "Unreleased",
+				Changes: []*ChangeList{
+					{Type: Fixed, Items: []*Item{{Description: "Timeout [#1]"}}},
+				},
+			},
+		},
+		Links: []*LinkDef{
+			{Label: "#1", URL: "https://example.com/api/issues/1"},
+			{Label: "#2", URL: "https://example.com/api/issues/2"},
+		},
+	}
+	web := &Changelog{
+		Versions: []*Version{
+			{
+				Name: "Unreleased",
+				Changes: []*ChangeList{
+					{Type: Fixed, Items: []*Item{{Description: "Login [#1]"}}},
+				},
+			},
+		},
+		Links: []*LinkDef{{Label: "#1", URL: "https://example.com/web/issues/1"}},
+	}
+
+	c := Aggregate([]Component{{Name: "api", Changelog: api}, {Name: "web", Changelog: web}}, nil)
+
+	assert.Equal(t, []*LinkDef{{Label: "#1", URL: "https://example.com/api/issues/1"}}, c.Links)
+}
@@ -1,55 +1,18 @@
 package chg
 
-//go:generate stringer -type=ChangeType
-
 import (
 	"fmt"
 	"io"
-	"strings"
 )
 
 // ChangeList groups the changes by type
-// Valid change types are "Added", "Changed", "Deprecated", "Fixed",
-// "Removed" and "Security"
+// Valid change types are the ones returned by ChangeTypes, by default
+// "Added", "Changed", "Deprecated", "Fixed", "Removed" and "Security"
 type ChangeList struct {
 	Type  ChangeType
 	Items []*Item
 }
 
-// ChangeType is the type of the changes
-type ChangeType int
-
-// Change types
-const (
-	Unknown ChangeType = iota
-	Added
-	Changed
-	Deprecated
-	Fixed
-	Removed
-	Security
-)
-
-// ChangeTypeFromString creates a type based on its string name
-func ChangeTypeFromString(ct string) ChangeType {
-	switch strings.ToLower(ct) {
-	case "added":
-		return Added
-	case "changed":
-		return Changed
-	case "deprecated":
-		return Deprecated
-	case "fixed":
-		return Fixed
-	case "removed":
-		return Removed
-	case "security":
-		return Security
-	default:
-		return Unknown
-	}
-}
-
 // NewChangeList creates a ChangeList struct based on the informed type
 func NewChangeList(ct string) *ChangeList {
 	changeType := ChangeTypeFromString(ct)
@@ -61,13 +24,24 @@ func NewChangeList(ct string) *ChangeList {
 
 // RenderItems renders all the items
 func (c *ChangeList) RenderItems(w io.Writer) {
+	c.renderItems(w, style)
+}
+
+func (c *ChangeList) renderItems(w io.Writer, s Style) {
 	for _, i := range c.Items {
-		i.Render(w)
+		i.render(w, s, s.Wrap)
 	}
 }
 
 // Render builds the representation of Change
 func (c *ChangeList) Render(w io.Writer) {
+	c.render(w, style)
+}
+
+func (c *ChangeList) render(w io.Writer, s Style) {
 	io.WriteString(w, fmt.Sprintf("### %s\n", c.Type.String()))
-	c.RenderItems(w)
+	if s.BlankLines == BlankLinesSpaced {
+		io.WriteString(w, "\n")
+	}
+	c.renderItems(w, s)
 }
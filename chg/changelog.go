@@ -11,8 +11,10 @@ import (
 // Changelog is the main struct that holds all the data
 // in a format specific to the spec
 type Changelog struct {
-	Preamble string
+	Title    string // Title of the document, "Changelog" when empty
+	Preamble string // Markdown content between the title and the versions
 	Versions []*Version
+	Links    []*LinkDef // Link definitions other than the versions' ones
 }
 
 // NewChangelog creates the Changelog struct
@@ -24,6 +26,7 @@ func NewChangelog() *Changelog {
 // NewEmptyChangelog creates the Changelog struct with default Preamble and Unreleased section
 func NewEmptyChangelog(unreleasedCompareURL string) *Changelog {
 	c := Changelog{
+		Title: "Changelog",
 		Preamble: `All notable changes to this project will be documented in this file.
 
 The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.0.0/)
@@ -58,6 +61,50 @@ func (c *Changelog) Version(version string) *Version {
 	return nil
 }
 
+// Latest returns the newest released version, or nil if there is none
+func (c *Changelog) Latest() *Version {
+	for _, v := range c.Versions {
+		if !v.IsUnreleased() {
+			return v
+		}
+	}
+	return nil
+}
+
+// Between returns the versions newer than from up to to (inclusive),
+// newest first. An empty from includes everything up to the oldest
+// version and an empty to starts at the newest one, Unreleased included.
+func (c *Changelog) Between(from, to string) ([]*Version, error) {
+	start, end := 0, len(c.Versions)
+
+	if to != "" {
+		start = c.versionIndex(to)
+		if start < 0 {
+			return nil, fmt.Errorf("Unknown version: '%s'", to)
+		}
+	}
+	if from != "" {
+		end = c.versionIndex(from)
+		if end < 0 {
+			return nil, fmt.Errorf("Unknown version: '%s'", from)
+		}
+	}
+
+	if start > end {
+		return nil, fmt.Errorf("Version '%s' is older than '%s'", to, from)
+	}
+	return c.Versions[start:end], nil
+}
+
+func (c *Changelog) versionIndex(version string) int {
+	for idx, v := range c.Versions {
+		if strings.ToLower(v.Name) == strings.ToLower(version) {
+			return idx
+		}
+	}
+	return -1
+}
+
 // AddItem includes the message under the proper section of Unreleased version
 func (c *Changelog) AddItem(section ChangeType, message string) {
 	v := c.Version("Unreleased")
@@ -66,19 +113,18 @@ func (c *Changelog) AddItem(section ChangeType, message string) {
 		c.Versions = append([]*Version{v}, c.Versions...)
 	}
 
-	s := v.Change(section)
-	if s == nil {
-		s = NewChangeList(section.String())
-		v.Changes = append(v.Changes, s)
-	}
-	item := &Item{
-		Description: message,
-	}
-	s.Items = append(s.Items, item)
+	v.AddItem(section, message)
 }
 
 // Release transforms Unreleased into the version informed
 func (c *Changelog) Release(newVersion Version) (*Version, error) {
+	return c.ReleaseWithTagPrefix(newVersion, "")
+}
+
+// ReleaseWithTagPrefix works like Release for projects where the
+// tags referenced by the compare links are the version name prefixed
+// with tagPrefix (eg. "pkg-a@1.2.0")
+func (c *Changelog) ReleaseWithTagPrefix(newVersion Version, tagPrefix string) (*Version, error) {
 	oldUnreleased := c.Version("Unreleased")
 	var prevVersion *Version
 	if len(c.Versions) > 1 {
@@ -96,22 +142,35 @@ func (c *Changelog) Release(newVersion Version) (*Version, error) {
 		return nil, fmt.Errorf("Could not infer the compare link")
 	}
 
+	newTag := tagPrefix + newVersion.Name
+
 	var compareURL string
 	if newVersion.Link != "" {
-		compareURL = strings.Replace(newVersion.Link, "<prev>", newVersion.Name, -1)
+		compareURL = strings.Replace(newVersion.Link, "<prev>", newTag, -1)
 		compareURL = strings.Replace(compareURL, "<next>", "HEAD", -1)
 	} else if prevVersion == nil || prevVersion.Link == "" {
 		r := regexp.MustCompile(`(\w[\w\.]*?)\.{2,3}(\w[\w\.]*?)$`)
-		r.MatchString(oldUnreleased.Link)
 		matches := r.FindStringSubmatch(oldUnreleased.Link)
-		compareURL = strings.Replace(oldUnreleased.Link, matches[1], newVersion.Name, -1)
+		if matches == nil {
+			return nil, fmt.Errorf("Could not infer the compare link from '%s'", oldUnreleased.Link)
+		}
+		prevTag := matches[1]
+		if tagPrefix != "" && strings.Contains(oldUnreleased.Link, tagPrefix+prevTag) {
+			prevTag = tagPrefix + prevTag
+		}
+		compareURL = strings.Replace(oldUnreleased.Link, prevTag, newTag, -1)
 	} else {
-		compareURL = strings.Replace(oldUnreleased.Link, prevVersion.Name, newVersion.Name, -1)
+		prevTag := tagPrefix + prevVersion.Name
+		if !strings.Contains(oldUnreleased.Link, prevTag) {
+			// Tags released before adopting the prefix
+			prevTag = prevVersion.Name
+		}
+		compareURL = strings.Replace(oldUnreleased.Link, prevTag, newTag, -1)
 	}
 
 	newUnreleased.Link = compareURL
 
-	oldUnreleased.Link = strings.Replace(oldUnreleased.Link, "HEAD", newVersion.Name, -1)
+	oldUnreleased.Link = strings.Replace(oldUnreleased.Link, "HEAD", newTag, -1)
 	oldUnreleased.Name = newVersion.Name
 	oldUnreleased.Date = newVersion.Date
 
@@ -120,18 +179,162 @@ func (c *Changelog) Release(newVersion Version) (*Version, error) {
 	return oldUnreleased, nil
 }
 
-// RenderLinks will render the links for each version
+// Unrelease reverts Release: the latest release, or the version informed
+// when it's the latest, is merged back into Unreleased
+func (c *Changelog) Unrelease(version string) (*Version, error) {
+	return c.UnreleaseWithTagPrefix(version, "")
+}
+
+// UnreleaseWithTagPrefix works like Unrelease for projects where the
+// tags are prefixed with tagPrefix, see ReleaseWithTagPrefix
+func (c *Changelog) UnreleaseWithTagPrefix(version, tagPrefix string) (*Version, error) {
+	latest := c.Latest()
+	if latest == nil {
+		return nil, fmt.Errorf("There are no releases")
+	}
+	if version != "" && !strings.EqualFold(version, latest.Name) {
+		v := c.Version(version)
+		switch {
+		case v == nil:
+			return nil, fmt.Errorf("Unknown version: '%s'", version)
+		case v.IsUnreleased():
+			return nil, fmt.Errorf("'%s' isn't released", version)
+		}
+		return nil, fmt.Errorf("Only the latest release can be unreleased, '%s' is newer than '%s'", latest.Name, version)
+	}
+
+	idx := c.versionIndex("Unreleased")
+	var unreleased *Version
+	if idx >= 0 {
+		unreleased = c.Versions[idx]
+		// Items added after the release go after the released ones
+		for _, cl := range unreleased.Changes {
+			if released := latest.Change(cl.Type); released != nil {
+				released.Items = append(released.Items, cl.Items...)
+			} else {
+				latest.Changes = append(latest.Changes, cl)
+			}
+		}
+		c.Versions = append(c.Versions[:idx], c.Versions[idx+1:]...)
+	}
+
+	tag := tagPrefix + latest.Name
+	if idx := strings.LastIndex(latest.Link, tag); idx >= 0 {
+		latest.Link = latest.Link[:idx] + "HEAD" + latest.Link[idx+len(tag):]
+	} else if unreleased != nil {
+		latest.Link = unreleased.Link
+	}
+
+	latest.Name = "Unreleased"
+	latest.Date = ""
+	latest.Yanked = false
+
+	return latest, nil
+}
+
+// RenameVersion renames a released version, updating its compare link
+// and the one of the newer version, which references it. When the
+// versions follow semantic versioning, the new name has to keep them
+// in order.
+func (c *Changelog) RenameVersion(oldName, newName string) (*Version, error) {
+	return c.RenameVersionWithTagPrefix(oldName, newName, "")
+}
+
+// RenameVersionWithTagPrefix works like RenameVersion for projects where
+// the tags are prefixed with tagPrefix, see ReleaseWithTagPrefix
+func (c *Changelog) RenameVersionWithTagPrefix(oldName, newName, tagPrefix string) (*Version, error) {
+	idx := c.versionIndex(oldName)
+	switch {
+	case idx < 0:
+		return nil, fmt.Errorf("Unknown version: '%s'", oldName)
+	case c.Versions[idx].IsUnreleased():
+		return nil, fmt.Errorf("'%s' can't be renamed", oldName)
+	case strings.TrimSpace(newName) == "" || strings.EqualFold(newName, "Unreleased"):
+		return nil, fmt.Errorf("Invalid version name '%s'", newName)
+	}
+	if other := c.versionIndex(newName); other >= 0 && other != idx {
+		return nil, fmt.Errorf("Version '%s' already exists", newName)
+	}
+	if err := c.checkOrder(idx, newName); err != nil {
+		return nil, err
+	}
+
+	v := c.Versions[idx]
+	oldTag, newTag := tagPrefix+v.Name, tagPrefix+newName
+	v.Link = replaceTag(v.Link, oldTag, newTag)
+	if idx > 0 {
+		newer := c.Versions[idx-1]
+		newer.Link = replaceTag(newer.Link, oldTag, newTag)
+	}
+	v.Name = newName
+
+	return v, nil
+}
+
+// checkOrder checks if the version at idx named name stays between the
+// released versions around it, when they follow semantic versioning
+func (c *Changelog) checkOrder(idx int, name string) error {
+	version, err := ParseSemVer(name)
+	if err != nil {
+		return nil
+	}
+
+	if idx > 0 && !c.Versions[idx-1].IsUnreleased() {
+		newer := c.Versions[idx-1].Name
+		if v, err := ParseSemVer(newer); err == nil && version.Compare(v) >= 0 {
+			return fmt.Errorf("'%s' has to precede the newer version '%s'", name, newer)
+		}
+	}
+	if idx+1 < len(c.Versions) {
+		older := c.Versions[idx+1].Name
+		if v, err := ParseSemVer(older); err == nil && version.Compare(v) <= 0 {
+			return fmt.Errorf("'%s' has to follow the older version '%s'", name, older)
+		}
+	}
+	return nil
+}
+
+// replaceTag replaces the tag in the link when it's a whole version,
+// eg. 1.2.0 in ".../compare/1.1.0...1.2.0" but not in 1.2.0-rc.1
+func replaceTag(link, oldTag, newTag string) string {
+	re := regexp.MustCompile(`(^|[^0-9.]|\.\.)` + regexp.QuoteMeta(oldTag) + `($|[^0-9A-Za-z+\-.]|\.\.)`)
+	return re.ReplaceAllString(link, "${1}"+strings.Replace(newTag, "$", "$$", -1)+"${2}")
+}
+
+// RenderLinks will render the links for each version followed by the
+// other link definitions. With the inline link style, the versions'
+// links are in the headings and aren't rendered.
 func (c *Changelog) RenderLinks(w io.Writer) {
-	for _, v := range c.Versions {
-		if v.Link != "" {
-			io.WriteString(w, fmt.Sprintf("[%s]: %s\n", v.Name, v.Link))
+	c.renderLinks(w, style)
+}
+
+func (c *Changelog) renderLinks(w io.Writer, s Style) {
+	if s.LinkStyle != LinkStyleInline {
+		for _, v := range c.Versions {
+			if v.Link != "" {
+				io.WriteString(w, fmt.Sprintf("[%s]: %s\n", v.Name, v.Link))
+			}
 		}
 	}
+	for _, l := range c.Links {
+		l.Render(w)
+	}
 }
 
 // Render outputs the full changelog contents
 func (c *Changelog) Render(w io.Writer) {
-	io.WriteString(w, "# Changelog\n")
+	c.RenderStyle(w, style)
+}
+
+// RenderStyle outputs the full changelog contents in the style instead
+// of the configured one. The style must be normalized (see
+// Style.Normalize).
+func (c *Changelog) RenderStyle(w io.Writer, s Style) {
+	title := c.Title
+	if title == "" {
+		title = "Changelog"
+	}
+	io.WriteString(w, fmt.Sprintf("# %s\n", title))
 	if preamble := strings.TrimSpace(c.Preamble); preamble != "" {
 		io.WriteString(w, "\n")
 		io.WriteString(w, preamble)
@@ -140,11 +343,11 @@ func (c *Changelog) Render(w io.Writer) {
 	for _, v := range c.Versions {
 		io.WriteString(w, "\n")
 		v.SortChanges()
-		v.Render(w)
+		v.RenderStyle(w, s)
 	}
 
 	var buf bytes.Buffer
-	c.RenderLinks(&buf)
+	c.renderLinks(&buf, s)
 	if content := buf.Bytes(); content != nil {
 		io.WriteString(w, "\n")
 		w.Write(content)
@@ -48,6 +48,57 @@ func TestChangelogVersion(t *testing.T) {
 	})
 }
 
+func TestChangelogLatest(t *testing.T) {
+	unreleased := &Version{Name: "Unreleased"}
+	v123 := &Version{Name: "1.2.3"}
+
+	c := NewChangelog()
+	c.Versions = []*Version{unreleased}
+	assert.Nil(t, c.Latest())
+
+	c.Versions = append(c.Versions, v123)
+	assert.Equal(t, v123, c.Latest())
+}
+
+func TestChangelogBetween(t *testing.T) {
+	unreleased := &Version{Name: "Unreleased"}
+	v3 := &Version{Name: "3.0.0"}
+	v2 := &Version{Name: "2.0.0"}
+	v1 := &Version{Name: "1.0.0"}
+
+	c := NewChangelog()
+	c.Versions = []*Version{unreleased, v3, v2, v1}
+
+	tests := map[string]struct {
+		from     string
+		to       string
+		expected []*Version
+		err      string
+	}{
+		"all":         {expected: []*Version{unreleased, v3, v2, v1}},
+		"since":       {from: "2.0.0", expected: []*Version{unreleased, v3}},
+		"until":       {to: "2.0.0", expected: []*Version{v2, v1}},
+		"range":       {from: "1.0.0", to: "3.0.0", expected: []*Version{v3, v2}},
+		"empty":       {from: "2.0.0", to: "2.0.0", expected: []*Version{}},
+		"unknown":     {from: "0.1.0", err: "Unknown version: '0.1.0'"},
+		"unknown to":  {to: "0.1.0", err: "Unknown version: '0.1.0'"},
+		"inverted":    {from: "3.0.0", to: "1.0.0", err: "Version '1.0.0' is older than '3.0.0'"},
+		"insensitive": {from: "3.0.0", to: "unreleased", expected: []*Version{unreleased}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			versions, err := c.Between(test.from, test.to)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, versions)
+		})
+	}
+}
+
 func TestChangelogAddItem(t *testing.T) {
 	t.Run("empty-changelog-added", func(t *testing.T) {
 		c := Changelog{}
@@ -305,3 +356,227 @@ to be inserted.
 		assert.Equal(t, expected, result)
 	})
 }
+
+func TestChangelogReleaseWithTagPrefix(t *testing.T) {
+	t.Run("previous-version", func(t *testing.T) {
+		c := Changelog{
+			Versions: []*Version{
+				{Name: "Unreleased", Link: "https://github.com/org/repo/compare/pkg-a@1.0.0...HEAD"},
+				{Name: "1.0.0", Link: "https://github.com/org/repo/compare/pkg-a@0.1.0...pkg-a@1.0.0"},
+			},
+		}
+
+		newVersion, err := c.ReleaseWithTagPrefix(Version{Name: "1.1.0"}, "pkg-a@")
+		assert.NoError(t, err)
+		assert.Equal(t, "1.1.0", newVersion.Name)
+		assert.Equal(t, "https://github.com/org/repo/compare/pkg-a@1.0.0...pkg-a@1.1.0", newVersion.Link)
+		assert.Equal(t, "https://github.com/org/repo/compare/pkg-a@1.1.0...HEAD", c.Version("Unreleased").Link)
+	})
+
+	t.Run("unprefixed-previous-version", func(t *testing.T) {
+		c := Changelog{
+			Versions: []*Version{
+				{Name: "Unreleased", Link: "https://github.com/org/repo/compare/1.0.0...HEAD"},
+				{Name: "1.0.0", Link: "https://github.com/org/repo/compare/0.1.0...1.0.0"},
+			},
+		}
+
+		newVersion, err := c.ReleaseWithTagPrefix(Version{Name: "1.1.0"}, "pkg-a@")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://github.com/org/repo/compare/1.0.0...pkg-a@1.1.0", newVersion.Link)
+		assert.Equal(t, "https://github.com/org/repo/compare/pkg-a@1.1.0...HEAD", c.Version("Unreleased").Link)
+	})
+
+	t.Run("parse-link", func(t *testing.T) {
+		c := Changelog{
+			Versions: []*Version{
+				{Name: "Unreleased", Link: "https://github.com/org/repo/compare/pkg-a@0.2.0...HEAD"},
+			},
+		}
+
+		newVersion, err := c.ReleaseWithTagPrefix(Version{Name: "1.0.0"}, "pkg-a@")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://github.com/org/repo/compare/pkg-a@0.2.0...pkg-a@1.0.0", newVersion.Link)
+		assert.Equal(t, "https://github.com/org/repo/compare/pkg-a@1.0.0...HEAD", c.Version("Unreleased").Link)
+	})
+}
+
+func TestChangelogUnrelease(t *testing.T) {
+	newChangelog := func() *Changelog {
+		return &Changelog{
+			Versions: []*Version{
+				{
+					Name: "Unreleased",
+					Link: "http://example.com/1.0.0...HEAD",
+					Changes: []*ChangeList{
+						{Type: Added, Items: []*Item{{Description: "Feature"}}},
+						{Type: Fixed, Items: []*Item{{Description: "Bug"}}},
+					},
+				},
+				{Name: "0.2.0", Date: "2020-01-01", Link: "http://example.com/0.1.0...0.2.0"},
+			},
+		}
+	}
+
+	t.Run("roundtrip", func(t *testing.T) {
+		c := newChangelog()
+		c.Release(Version{Name: "1.1.0", Date: "2020-02-01"})
+		c.Version("Unreleased").AddItem(Fixed, "Late bug")
+
+		v, err := c.Unrelease("")
+
+		assert.Nil(t, err)
+		assert.Equal(t, &Version{
+			Name: "Unreleased",
+			Link: "http://example.com/1.0.0...HEAD",
+			Changes: []*ChangeList{
+				{Type: Added, Items: []*Item{{Description: "Feature"}}},
+				{Type: Fixed, Items: []*Item{{Description: "Bug"}, {Description: "Late bug"}}},
+			},
+		}, v)
+		assert.Equal(t, []*Version{v, c.Version("0.2.0")}, c.Versions)
+	})
+
+	t.Run("named", func(t *testing.T) {
+		c := newChangelog()
+		c.Release(Version{Name: "1.1.0", Date: "2020-02-01"})
+
+		_, err := c.Unrelease("0.2.0")
+		assert.EqualError(t, err, "Only the latest release can be unreleased, '1.1.0' is newer than '0.2.0'")
+
+		_, err = c.Unrelease("3.0.0")
+		assert.EqualError(t, err, "Unknown version: '3.0.0'")
+
+		_, err = c.Unrelease("unreleased")
+		assert.EqualError(t, err, "'unreleased' isn't released")
+
+		v, err := c.Unrelease("1.1.0")
+		assert.Nil(t, err)
+		assert.Equal(t, "Unreleased", v.Name)
+		assert.Len(t, c.Versions, 2)
+	})
+
+	t.Run("without-unreleased", func(t *testing.T) {
+		c := newChangelog()
+		c.Versions = c.Versions[1:]
+
+		v, err := c.Unrelease("")
+
+		assert.Nil(t, err)
+		assert.Equal(t, &Version{Name: "Unreleased", Link: "http://example.com/0.1.0...HEAD"}, v)
+	})
+
+	t.Run("tag-prefix", func(t *testing.T) {
+		c := &Changelog{
+			Versions: []*Version{
+				{Name: "Unreleased", Link: "https://github.com/org/repo/compare/pkg-a@1.0.0...HEAD"},
+				{Name: "1.0.0", Link: "https://github.com/org/repo/compare/pkg-a@0.1.0...pkg-a@1.0.0"},
+			},
+		}
+
+		v, err := c.UnreleaseWithTagPrefix("", "pkg-a@")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "https://github.com/org/repo/compare/pkg-a@0.1.0...HEAD", v.Link)
+	})
+
+	t.Run("no-releases", func(t *testing.T) {
+		_, err := NewEmptyChangelog("").Unrelease("")
+
+		assert.EqualError(t, err, "There are no releases")
+	})
+}
+
+func TestChangelogRenameVersion(t *testing.T) {
+	newChangelog := func() *Changelog {
+		return &Changelog{
+			Versions: []*Version{
+				{Name: "Unreleased", Link: "https://example.com/compare/v1.3.0...HEAD"},
+				{Name: "1.3.0", Link: "https://example.com/compare/v1.2.0...v1.3.0"},
+				{Name: "1.2.0", Link: "https://example.com/compare/v1.1.0...v1.2.0"},
+				{Name: "1.1.0", Link: "https://example.com/releases/tag/v1.1.0"},
+			},
+		}
+	}
+
+	t.Run("links", func(t *testing.T) {
+		c := newChangelog()
+
+		v, err := c.RenameVersion("1.3.0", "1.3.0-beta.1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "1.3.0-beta.1", v.Name)
+		assert.Equal(t, "https://example.com/compare/v1.2.0...v1.3.0-beta.1", v.Link)
+		assert.Equal(t, "https://example.com/compare/v1.3.0-beta.1...HEAD", c.Versions[0].Link)
+		assert.Equal(t, "https://example.com/compare/v1.1.0...v1.2.0", c.Versions[2].Link)
+	})
+
+	t.Run("first-release", func(t *testing.T) {
+		c := newChangelog()
+
+		_, err := c.RenameVersion("1.1.0", "1.0.0")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "https://example.com/releases/tag/v1.0.0", c.Versions[3].Link)
+		assert.Equal(t, "https://example.com/compare/v1.0.0...v1.2.0", c.Versions[2].Link)
+	})
+
+	t.Run("not-semver", func(t *testing.T) {
+		c := newChangelog()
+
+		_, err := c.RenameVersion("1.2.0", "1.2")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "https://example.com/compare/v1.2...v1.3.0", c.Versions[1].Link)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := map[string][2]string{
+			"Unknown version: '2.0.0'":                             {"2.0.0", "2.0.1"},
+			"'unreleased' can't be renamed":                        {"unreleased", "2.0.0"},
+			"Invalid version name 'Unreleased'":                    {"1.3.0", "Unreleased"},
+			"Version '1.2.0' already exists":                       {"1.3.0", "1.2.0"},
+			"'1.4.0' has to precede the newer version '1.3.0'":     {"1.2.0", "1.4.0"},
+			"'1.2.0-rc.1' has to follow the older version '1.2.0'": {"1.3.0", "1.2.0-rc.1"},
+			"'1.1.5' has to follow the older version '1.2.0'":      {"1.3.0", "1.1.5"},
+		}
+
+		for expected, names := range tests {
+			_, err := newChangelog().RenameVersion(names[0], names[1])
+
+			assert.EqualError(t, err, expected)
+		}
+	})
+
+	t.Run("tag-prefix", func(t *testing.T) {
+		c := &Changelog{
+			Versions: []*Version{
+				{Name: "Unreleased", Link: "https://github.com/org/repo/compare/pkg-a@1.0.0...HEAD"},
+				{Name: "1.0.0", Link: "https://github.com/org/repo/compare/pkg-a@0.1.0...pkg-a@1.0.0"},
+			},
+		}
+
+		_, err := c.RenameVersionWithTagPrefix("1.0.0", "1.0.1", "pkg-a@")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "https://github.com/org/repo/compare/pkg-a@1.0.1...HEAD", c.Versions[0].Link)
+		assert.Equal(t, "https://github.com/org/repo/compare/pkg-a@0.1.0...pkg-a@1.0.1", c.Versions[1].Link)
+	})
+}
+
+func TestReplaceTag(t *testing.T) {
+	tests := []struct {
+		link, expected string
+	}{
+		{"https://example.com/compare/1.1.0...1.2.0", "https://example.com/compare/1.1.0...2.0.0"},
+		{"https://example.com/compare/1.2.0..HEAD", "https://example.com/compare/2.0.0..HEAD"},
+		{"https://example.com/compare/v1.2.0...v1.3.0", "https://example.com/compare/v2.0.0...v1.3.0"},
+		{"https://example.com/compare/1.2.0-rc.1...1.2.0", "https://example.com/compare/1.2.0-rc.1...2.0.0"},
+		{"https://example.com/tree/11.2.0", "https://example.com/tree/11.2.0"},
+		{"https://example.com/tree/1.2.0.1", "https://example.com/tree/1.2.0.1"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, replaceTag(test.link, "1.2.0", "2.0.0"), test.link)
+	}
+}
@@ -0,0 +1,163 @@
+package chg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ChangeType is the type of the changes
+type ChangeType int
+
+// Change types
+const (
+	Unknown ChangeType = iota
+	Added
+	Changed
+	Deprecated
+	Fixed
+	Removed
+	Security
+)
+
+// ChangeTypeDef describes a change type: its canonical name, the
+// heading used when rendering it and the alternative headings
+// accepted when parsing.
+type ChangeTypeDef struct {
+	Name    string   // Canonical name, eg. "Fixed"
+	Heading string   // Heading used in the file; defaults to Name
+	Aliases []string // Other headings recognized as this type
+}
+
+// DefaultChangeTypes are the change types defined by keepachangelog.com
+var DefaultChangeTypes = []ChangeTypeDef{
+	{Name: "Added"},
+	{Name: "Changed"},
+	{Name: "Deprecated"},
+	{Name: "Fixed"},
+	{Name: "Removed"},
+	{Name: "Security"},
+}
+
+var standardChangeTypes = map[string]ChangeType{
+	"added":      Added,
+	"changed":    Changed,
+	"deprecated": Deprecated,
+	"fixed":      Fixed,
+	"removed":    Removed,
+	"security":   Security,
+}
+
+type changeTypeInfo struct {
+	def   ChangeTypeDef
+	order int
+}
+
+// configured change types, indexed by their value
+var changeTypes map[ChangeType]*changeTypeInfo
+
+// configured change types, in the order they should be rendered
+var changeTypeOrder []ChangeType
+
+func init() {
+	SetChangeTypes(DefaultChangeTypes)
+}
+
+// SetChangeTypes replaces the known change types with defs. The order
+// of defs defines the order of the sections when rendering.
+//
+// The standard types keep their constant values (eg. Fixed) when
+// present in defs; other types receive new values.
+func SetChangeTypes(defs []ChangeTypeDef) error {
+	if len(defs) == 0 {
+		return fmt.Errorf("at least one change type is required")
+	}
+
+	types := make(map[ChangeType]*changeTypeInfo, len(defs))
+	order := make([]ChangeType, 0, len(defs))
+	seen := make(map[string]string)
+	next := Security + 1
+
+	for idx, def := range defs {
+		def.Name = strings.TrimSpace(def.Name)
+		if def.Name == "" {
+			return fmt.Errorf("change type #%d has no name", idx+1)
+		}
+		if def.Heading == "" {
+			def.Heading = def.Name
+		}
+
+		for _, label := range append([]string{def.Name, def.Heading}, def.Aliases...) {
+			key := strings.ToLower(label)
+			if owner, ok := seen[key]; ok && owner != def.Name {
+				return fmt.Errorf("change type '%s' conflicts with '%s'", label, owner)
+			}
+			seen[key] = def.Name
+		}
+
+		ct, ok := standardChangeTypes[strings.ToLower(def.Name)]
+		if !ok {
+			ct = next
+			next++
+		}
+		types[ct] = &changeTypeInfo{def: def, order: idx}
+		order = append(order, ct)
+	}
+
+	changeTypes = types
+	changeTypeOrder = order
+	return nil
+}
+
+// ChangeTypes returns the configured change types in rendering order
+func ChangeTypes() []ChangeType {
+	result := make([]ChangeType, len(changeTypeOrder))
+	copy(result, changeTypeOrder)
+	return result
+}
+
+// ChangeTypeFromString creates a type based on its name, heading or
+// one of its aliases. The comparison is case-insensitive.
+func ChangeTypeFromString(ct string) ChangeType {
+	ct = strings.ToLower(strings.TrimSpace(ct))
+	for _, t := range changeTypeOrder {
+		def := changeTypes[t].def
+		if strings.ToLower(def.Name) == ct || strings.ToLower(def.Heading) == ct {
+			return t
+		}
+		for _, alias := range def.Aliases {
+			if strings.ToLower(alias) == ct {
+				return t
+			}
+		}
+	}
+	return Unknown
+}
+
+// Name returns the canonical name of the change type
+func (ct ChangeType) Name() string {
+	if info, ok := changeTypes[ct]; ok {
+		return info.def.Name
+	}
+	if ct == Unknown {
+		return "Unknown"
+	}
+	return "ChangeType(" + strconv.FormatInt(int64(ct), 10) + ")"
+}
+
+// String returns the heading used for the change type
+func (ct ChangeType) String() string {
+	if info, ok := changeTypes[ct]; ok {
+		return info.def.Heading
+	}
+	return ct.Name()
+}
+
+// order returns the position of the type when rendering. Types that
+// are not configured go last.
+func (ct ChangeType) order() int {
+	if info, ok := changeTypes[ct]; ok {
+		return info.order
+	}
+	return len(changeTypeOrder) + int(ct)
+}
@@ -0,0 +1,84 @@
+package chg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetChangeTypes(t *testing.T) {
+	defer SetChangeTypes(DefaultChangeTypes)
+
+	err := SetChangeTypes([]ChangeTypeDef{
+		{Name: "Added", Heading: "Adicionado"},
+		{Name: "Fixed", Heading: "Corrigido", Aliases: []string{"Bugfixes"}},
+		{Name: "Performance"},
+	})
+	assert.NoError(t, err)
+
+	perf := ChangeTypeFromString("performance")
+	assert.NotEqual(t, Unknown, perf)
+	assert.Equal(t, "Performance", perf.String())
+
+	t.Run("standard-values-kept", func(t *testing.T) {
+		assert.Equal(t, Fixed, ChangeTypeFromString("Fixed"))
+		assert.Equal(t, Fixed, ChangeTypeFromString("corrigido"))
+		assert.Equal(t, Fixed, ChangeTypeFromString("Bugfixes"))
+		assert.Equal(t, Unknown, ChangeTypeFromString("Security"))
+	})
+
+	t.Run("heading", func(t *testing.T) {
+		assert.Equal(t, "Added", Added.Name())
+		assert.Equal(t, "Adicionado", Added.String())
+	})
+
+	t.Run("order", func(t *testing.T) {
+		assert.Equal(t, []ChangeType{Added, Fixed, perf}, ChangeTypes())
+	})
+
+	t.Run("render", func(t *testing.T) {
+		v := &Version{
+			Name: "1.0.0",
+			Changes: []*ChangeList{
+				{Type: perf, Items: []*Item{{Description: "Faster"}}},
+				{Type: Fixed, Items: []*Item{{Description: "Bug"}}},
+			},
+		}
+		expected := "### Corrigido\n- Bug\n\n### Performance\n- Faster\n"
+
+		v.SortChanges()
+		var buf bytes.Buffer
+		v.RenderChanges(&buf)
+		assert.Equal(t, expected, buf.String())
+	})
+}
+
+func TestSetChangeTypesInvalid(t *testing.T) {
+	defer SetChangeTypes(DefaultChangeTypes)
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Error(t, SetChangeTypes(nil))
+	})
+
+	t.Run("no-name", func(t *testing.T) {
+		assert.Error(t, SetChangeTypes([]ChangeTypeDef{{Heading: "Adicionado"}}))
+	})
+
+	t.Run("conflicting-alias", func(t *testing.T) {
+		err := SetChangeTypes([]ChangeTypeDef{
+			{Name: "Added"},
+			{Name: "Fixed", Aliases: []string{"added"}},
+		})
+		assert.Error(t, err)
+	})
+
+	// Failures must not change the configured types
+	assert.Equal(t, Security, ChangeTypeFromString("Security"))
+}
+
+func TestChangeTypeString(t *testing.T) {
+	assert.Equal(t, "Security", Security.String())
+	assert.Equal(t, "Unknown", Unknown.String())
+	assert.Equal(t, "ChangeType(42)", ChangeType(42).String())
+}
@@ -0,0 +1,91 @@
+package chg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ISODate is the layout of the dates defined by keepachangelog.com
+const ISODate = "2006-01-02"
+
+// DateLayouts are the formats recognized by ParseDate, in order.
+// Slashed dates are read day first (eg. 31/01/2024).
+var DateLayouts = []string{
+	ISODate,
+	"2006-1-2",
+	"2006.01.02",
+	"2006.1.2",
+	"2006/01/02",
+	"02/01/2006",
+	"2/1/2006",
+	"02.01.2006",
+	"January 2, 2006",
+	"January 2 2006",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// ParseDate parses a release date in the configured date format or in
+// one of DateLayouts. Month names are case-insensitive.
+func ParseDate(date string) (time.Time, error) {
+	return parseDate(date, style.dateLayouts())
+}
+
+// dateLayouts returns the layouts tried when parsing with the style: its
+// date format, then DateLayouts
+func (s Style) dateLayouts() []string {
+	if s.DateFormat == ISODate {
+		return DateLayouts
+	}
+	return append([]string{s.DateFormat}, DateLayouts...)
+}
+
+// parseDate parses the date in the first of layouts that matches
+func parseDate(date string, layouts []string) (time.Time, error) {
+	date = strings.Join(strings.Fields(date), " ")
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date '%s'", date)
+}
+
+// NormalizeDate returns the date in the ISO format, or as is when it
+// isn't a valid date
+func NormalizeDate(date string) string {
+	return formatDate(date, style, ISODate)
+}
+
+// hashDate returns the date in the ISO format like NormalizeDate, but
+// only trying DateLayouts: the hashes can't change with the configured
+// date format
+func hashDate(date string) string {
+	t, err := parseDate(date, DateLayouts)
+	if err != nil {
+		return date
+	}
+	return t.Format(ISODate)
+}
+
+// formatDate returns the date in layout, or as is when it isn't a
+// valid date in the style
+func formatDate(date string, s Style, layout string) string {
+	t, err := parseDate(date, s.dateLayouts())
+	if err != nil {
+		return date
+	}
+	return t.Format(layout)
+}
+
+// ReleaseTime returns the release date of the version
+func (v *Version) ReleaseTime() (time.Time, error) {
+	return ParseDate(v.Date)
+}
@@ -0,0 +1,64 @@
+package chg
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+	expected := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, date := range []string{
+		"2024-01-31",
+		"2024.01.31",
+		"2024/01/31",
+		"31/01/2024",
+		"31.01.2024",
+		"January 31, 2024",
+		"january  31, 2024",
+		"Jan 31 2024",
+		"31 January 2024",
+	} {
+		t.Run(date, func(t *testing.T) {
+			d, err := ParseDate(date)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, d)
+		})
+	}
+
+	d, err := ParseDate("2024-01-31T23:00:00-03:00")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-31", d.Format(ISODate))
+
+	_, err = ParseDate("2024-13-01")
+	assert.EqualError(t, err, "invalid date '2024-13-01'")
+}
+
+func TestNormalizeDate(t *testing.T) {
+	assert.Equal(t, "2024-01-31", NormalizeDate("31/01/2024"))
+	assert.Equal(t, "soon", NormalizeDate("soon"))
+}
+
+func TestVersionRenderTitleDateFormat(t *testing.T) {
+	defer SetStyle(DefaultStyle)
+
+	v := &Version{Name: "1.0.0", Date: "January 31, 2024"}
+
+	var buf bytes.Buffer
+	v.RenderTitle(&buf)
+	assert.Equal(t, "## 1.0.0 - 2024-01-31", buf.String())
+
+	assert.NoError(t, SetStyle(Style{DateFormat: "02/01/2006"}))
+	buf.Reset()
+	v.RenderTitle(&buf)
+	assert.Equal(t, "## 1.0.0 - 31/01/2024", buf.String())
+
+	v.Date = "01/02/2024"
+	buf.Reset()
+	v.RenderTitle(&buf)
+	assert.Equal(t, "## 1.0.0 - 01/02/2024", buf.String())
+	assert.Equal(t, "2024-02-01", NormalizeDate(v.Date))
+}
@@ -0,0 +1,156 @@
+package chg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffKind tells how something changed between two changelogs
+type DiffKind string
+
+// Kinds of differences
+const (
+	DiffAdded    DiffKind = "added"
+	DiffRemoved  DiffKind = "removed"
+	DiffModified DiffKind = "modified"
+)
+
+// Difference describes a change between two changelogs
+//
+// Field is empty when a whole version was added or removed. Otherwise
+// it's one of "preamble", "date", "link", "yanked", "item" or "type"
+// (an item moved to another section).
+type Difference struct {
+	Kind    DiffKind `json:"kind"`
+	Version string   `json:"version,omitempty"`
+	Field   string   `json:"field,omitempty"`
+	Type    string   `json:"type,omitempty"` // Change type of the item
+	Item    string   `json:"item,omitempty"` // Item description
+	Old     string   `json:"old,omitempty"`
+	New     string   `json:"new,omitempty"`
+}
+
+func (d Difference) String() string {
+	sign := map[DiffKind]string{DiffAdded: "+", DiffRemoved: "-", DiffModified: "~"}[d.Kind]
+
+	switch d.Field {
+	case "":
+		return fmt.Sprintf("%s version %s", sign, d.Version)
+	case "preamble":
+		return fmt.Sprintf("%s preamble", sign)
+	case "item":
+		return fmt.Sprintf("%s %s: %s: %s", sign, d.Version, d.Type, normalizeText(d.Item))
+	case "type":
+		return fmt.Sprintf("%s %s: moved from %s to %s: %s", sign, d.Version, d.Old, d.New, normalizeText(d.Item))
+	default:
+		return fmt.Sprintf("%s %s: %s changed from %q to %q", sign, d.Version, d.Field, d.Old, d.New)
+	}
+}
+
+// Diff compares two changelogs and returns the differences needed to
+// go from a to b. Cosmetic differences normalized by Render, like the
+// order of the sections, list bullets and line wrapping, are ignored.
+func Diff(a, b *Changelog) []Difference {
+	var diffs []Difference
+
+	if normalizeText(a.Preamble) != normalizeText(b.Preamble) {
+		diffs = append(diffs, Difference{Kind: DiffModified, Field: "preamble", Old: a.Preamble, New: b.Preamble})
+	}
+
+	for _, vb := range b.Versions {
+		va := a.Version(vb.Name)
+		if va == nil {
+			diffs = append(diffs, Difference{Kind: DiffAdded, Version: vb.Name})
+			continue
+		}
+		diffs = append(diffs, diffVersions(va, vb)...)
+	}
+
+	for _, va := range a.Versions {
+		if b.Version(va.Name) == nil {
+			diffs = append(diffs, Difference{Kind: DiffRemoved, Version: va.Name})
+		}
+	}
+
+	return diffs
+}
+
+func diffVersions(a, b *Version) []Difference {
+	var diffs []Difference
+
+	modified := func(field, old, new string) {
+		if old != new {
+			diffs = append(diffs, Difference{Kind: DiffModified, Version: b.Name, Field: field, Old: old, New: new})
+		}
+	}
+	if hashDate(a.Date) != hashDate(b.Date) {
+		// Dates in other formats are the same release date
+		modified("date", a.Date, b.Date)
+	}
+	modified("link", a.Link, b.Link)
+	modified("yanked", fmt.Sprint(a.Yanked), fmt.Sprint(b.Yanked))
+	modified("superseded", fmt.Sprint(a.Superseded), fmt.Sprint(b.Superseded))
+
+	oldItems := versionItems(a)
+	newItems := versionItems(b)
+
+	var added []Difference
+	for _, item := range newItems {
+		if idx := findItem(oldItems, item); idx >= 0 {
+			oldItems = append(oldItems[:idx], oldItems[idx+1:]...)
+			continue
+		}
+		added = append(added, Difference{Kind: DiffAdded, Version: b.Name, Field: "item", Type: item.changeType.String(), Item: item.description})
+	}
+
+	var removed []Difference
+	for _, item := range oldItems {
+		removed = append(removed, Difference{Kind: DiffRemoved, Version: b.Name, Field: "item", Type: item.changeType.String(), Item: item.description})
+	}
+
+	// Items removed from a section and added to another were moved
+	for i := 0; i < len(added); i++ {
+		for j := 0; j < len(removed); j++ {
+			if normalizeText(added[i].Item) == normalizeText(removed[j].Item) {
+				diffs = append(diffs, Difference{Kind: DiffModified, Version: b.Name, Field: "type", Item: added[i].Item, Old: removed[j].Type, New: added[i].Type})
+				added = append(added[:i], added[i+1:]...)
+				removed = append(removed[:j], removed[j+1:]...)
+				i--
+				break
+			}
+		}
+	}
+
+	diffs = append(diffs, added...)
+	return append(diffs, removed...)
+}
+
+type versionItem struct {
+	changeType  ChangeType
+	description string
+}
+
+func versionItems(v *Version) []versionItem {
+	var items []versionItem
+	for _, change := range v.Changes {
+		for _, item := range change.Items {
+			items = append(items, versionItem{changeType: change.Type, description: item.Text()})
+		}
+	}
+	return items
+}
+
+func findItem(items []versionItem, item versionItem) int {
+	for idx, i := range items {
+		if i.changeType == item.changeType && normalizeText(i.description) == normalizeText(item.description) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// normalizeText collapses whitespace, as re-wrapping a text doesn't
+// change it
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,79 @@
+package chg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	a := &Changelog{
+		Preamble: "Some\ntext",
+		Versions: []*Version{
+			{
+				Name: "Unreleased",
+				Changes: []*ChangeList{
+					{Type: Fixed, Items: []*Item{{Description: "Bug"}}},
+					{Type: Added, Items: []*Item{{Description: "A long item\nwrapped"}, {Description: "Moved"}}},
+				},
+			},
+			{Name: "1.0.0", Date: "2020-01-01", Link: "http://example.com/1.0.0"},
+			{Name: "0.1.0", Date: "2019-01-01"},
+		},
+	}
+
+	b := &Changelog{
+		Preamble: "Some text",
+		Versions: []*Version{
+			{
+				Name: "Unreleased",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{{Description: "A long item wrapped"}, {Description: "New"}}},
+					{Type: Changed, Items: []*Item{{Description: "Moved"}}},
+				},
+			},
+			{Name: "1.1.0", Date: "2020-02-01"},
+			{Name: "1.0.0", Date: "2020-01-02", Link: "http://example.com/1.0.0", Yanked: true},
+		},
+	}
+
+	expected := []Difference{
+		{Kind: DiffModified, Version: "Unreleased", Field: "type", Item: "Moved", Old: "Added", New: "Changed"},
+		{Kind: DiffAdded, Version: "Unreleased", Field: "item", Type: "Added", Item: "New"},
+		{Kind: DiffRemoved, Version: "Unreleased", Field: "item", Type: "Fixed", Item: "Bug"},
+		{Kind: DiffAdded, Version: "1.1.0"},
+		{Kind: DiffModified, Version: "1.0.0", Field: "date", Old: "2020-01-01", New: "2020-01-02"},
+		{Kind: DiffModified, Version: "1.0.0", Field: "yanked", Old: "false", New: "true"},
+		{Kind: DiffRemoved, Version: "0.1.0"},
+	}
+
+	assert.Equal(t, expected, Diff(a, b))
+	assert.Empty(t, Diff(a, a))
+}
+
+func TestDiffDateFormat(t *testing.T) {
+	a := &Changelog{Versions: []*Version{{Name: "1.0.0", Date: "January 31, 2024"}}}
+	b := &Changelog{Versions: []*Version{{Name: "1.0.0", Date: "2024-01-31"}}}
+
+	assert.Empty(t, Diff(a, b))
+}
+
+func TestDifferenceString(t *testing.T) {
+	var testData = []struct {
+		diff     Difference
+		expected string
+	}{
+		{Difference{Kind: DiffAdded, Version: "1.1.0"}, "+ version 1.1.0"},
+		{Difference{Kind: DiffRemoved, Version: "0.1.0"}, "- version 0.1.0"},
+		{Difference{Kind: DiffModified, Field: "preamble"}, "~ preamble"},
+		{Difference{Kind: DiffAdded, Version: "1.0.0", Field: "item", Type: "Added", Item: "New"}, "+ 1.0.0: Added: New"},
+		{Difference{Kind: DiffModified, Version: "1.0.0", Field: "type", Item: "Moved", Old: "Added", New: "Fixed"}, "~ 1.0.0: moved from Added to Fixed: Moved"},
+		{Difference{Kind: DiffModified, Version: "1.0.0", Field: "date", Old: "2020-01-01", New: "2020-01-02"}, `~ 1.0.0: date changed from "2020-01-01" to "2020-01-02"`},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.diff.String())
+		})
+	}
+}
@@ -1,16 +1,90 @@
 package chg
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"strings"
 )
 
 // Item holds the change itself
 type Item struct {
-	Description string
+	Description string   // First paragraph of the item
+	Body        []string // Following paragraphs and code blocks, as markdown
+	Children    []*Item  // Nested list items, rendered after the body
 }
 
-// Render rendes the change as a list item
+// Render rendes the change as a list item using the configured style.
+// The body and the children are indented by 4 spaces, which is what
+// parsers following the original markdown syntax require to keep them
+// in the item.
 func (i *Item) Render(w io.Writer) {
-	io.WriteString(w, fmt.Sprintf("- %s\n", i.Description))
+	i.render(w, style, style.Wrap)
+}
+
+// render writes the item with lines of up to width characters, when
+// wrapping is enabled
+func (i *Item) render(w io.Writer, s Style, width int) {
+	lines := strings.Split(s.styleParagraph(i.Description, width-2), "\n")
+	io.WriteString(w, fmt.Sprintf("%s %s\n", s.Bullet, lines[0]))
+	writeIndented(w, strings.Join(lines[1:], "\n"), "  ")
+
+	for _, block := range i.Body {
+		if isParagraph(block) {
+			block = s.styleParagraph(block, width-4)
+		}
+		io.WriteString(w, "\n")
+		writeIndented(w, block, "    ")
+	}
+
+	if len(i.Children) > 0 && len(i.Body) > 0 {
+		io.WriteString(w, "\n")
+	}
+	for _, child := range i.Children {
+		var buf bytes.Buffer
+		child.render(&buf, s, width-4)
+		writeIndented(w, buf.String(), "    ")
+	}
+}
+
+// Text returns the markdown content of the item, without the list
+// marker. For items without body and children it's the description.
+// It doesn't depend on the configured style.
+func (i *Item) Text() string {
+	var buf bytes.Buffer
+	buf.WriteString(i.Description)
+
+	for _, block := range i.Body {
+		buf.WriteString("\n\n")
+		buf.WriteString(strings.TrimRight(block, "\n"))
+	}
+
+	if len(i.Children) > 0 {
+		if len(i.Body) > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\n")
+		for _, child := range i.Children {
+			child.render(&buf, DefaultStyle, 0)
+		}
+	}
+
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+// writeIndented writes the lines of text with the indentation, keeping
+// the blank ones empty
+func writeIndented(w io.Writer, text, indent string) {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			io.WriteString(w, "\n")
+		} else {
+			io.WriteString(w, indent+line+"\n")
+		}
+	}
 }
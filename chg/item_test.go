@@ -8,7 +8,7 @@ import (
 )
 
 func TestItemRender(t *testing.T) {
-	i := Item{"Item 1"}
+	i := Item{Description: "Item 1"}
 	expected := "- Item 1\n"
 
 	var buf bytes.Buffer
@@ -17,3 +17,46 @@ func TestItemRender(t *testing.T) {
 
 	assert.Equal(t, expected, result)
 }
+
+func TestItemRenderMultiline(t *testing.T) {
+	i := Item{
+		Description: "Item 1\nsecond line",
+		Body:        []string{"Second paragraph", "```go\nfunc main() {\n\n}\n```"},
+		Children: []*Item{
+			{Description: "Child 1", Children: []*Item{{Description: "Grandchild"}}},
+			{Description: "Child 2"},
+		},
+	}
+	expected := `- Item 1
+  second line
+
+    Second paragraph
+
+    ` + "```go" + `
+    func main() {
+
+    }
+    ` + "```" + `
+
+    - Child 1
+        - Grandchild
+    - Child 2
+`
+
+	var buf bytes.Buffer
+	i.Render(&buf)
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestItemText(t *testing.T) {
+	i := Item{Description: "Item 1"}
+	assert.Equal(t, "Item 1", i.Text())
+
+	i = Item{
+		Description: "Item 1",
+		Body:        []string{"Paragraph"},
+		Children:    []*Item{{Description: "Child"}},
+	}
+	assert.Equal(t, "Item 1\n\nParagraph\n\n- Child", i.Text())
+}
@@ -0,0 +1,175 @@
+package chg
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// LinkDef is a link reference definition, eg. "[#12]: https://example.com/issues/12"
+type LinkDef struct {
+	Label string
+	URL   string
+	Title string
+}
+
+// Render writes the definition in its own line
+func (l *LinkDef) Render(w io.Writer) {
+	if l.Title != "" {
+		io.WriteString(w, fmt.Sprintf("[%s]: %s \"%s\"\n", l.Label, l.URL, l.Title))
+	} else {
+		io.WriteString(w, fmt.Sprintf("[%s]: %s\n", l.Label, l.URL))
+	}
+}
+
+// Reference is a reference-style link found in markdown text
+type Reference struct {
+	Label string // Label of the definition, eg. "#12" in "[issue 12][#12]"
+	// Shortcut references (eg. "[#12]") are only links when the label
+	// is defined; otherwise they are plain text
+	Shortcut bool
+}
+
+var reReference = regexp.MustCompile(`\[([^\[\]]+)\](?:\[([^\[\]]*)\]|(\()|(:))?`)
+
+// References returns the reference-style links in text, in order.
+// Inline links and code spans are ignored.
+func References(text string) []Reference {
+	var refs []Reference
+
+	text = stripCodeSpans(text)
+	for _, m := range reReference.FindAllStringSubmatchIndex(text, -1) {
+		start := m[0]
+		if start > 0 && (text[start-1] == '\\' || text[start-1] == ']') {
+			continue
+		}
+
+		label := text[m[2]:m[3]]
+		switch {
+		case m[6] >= 0 || m[8] >= 0: // inline link or definition
+			continue
+		case m[4] >= 0:
+			if l := text[m[4]:m[5]]; l != "" {
+				label = l
+			}
+			refs = append(refs, Reference{Label: label})
+		default:
+			refs = append(refs, Reference{Label: label, Shortcut: true})
+		}
+	}
+
+	return refs
+}
+
+// stripCodeSpans replaces the code spans with spaces, keeping the
+// positions of the rest of the text
+func stripCodeSpans(text string) string {
+	if !strings.Contains(text, "`") {
+		return text
+	}
+
+	runes := []rune(text)
+	for idx := 0; idx < len(runes); idx++ {
+		if runes[idx] != '`' {
+			continue
+		}
+		end := closingBackticks(runes, idx)
+		for i := idx; i < end; i++ {
+			if runes[i] != '\n' {
+				runes[i] = ' '
+			}
+		}
+		idx = end - 1
+	}
+	return string(runes)
+}
+
+// normalizeLabel returns the label in the form used to match
+// references and definitions: case-insensitive and with collapsed
+// whitespace
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+// Link returns the definition of label, or nil if it's not defined.
+// Version links are also definitions, named after the version.
+func (c *Changelog) Link(label string) *LinkDef {
+	label = normalizeLabel(label)
+	for _, l := range c.Links {
+		if normalizeLabel(l.Label) == label {
+			return l
+		}
+	}
+	for _, v := range c.Versions {
+		if v.Link != "" && normalizeLabel(v.Name) == label {
+			return &LinkDef{Label: v.Name, URL: v.Link}
+		}
+	}
+	return nil
+}
+
+// AddLink adds the definition, replacing the one with the same label
+func (c *Changelog) AddLink(l *LinkDef) {
+	label := normalizeLabel(l.Label)
+	for idx, existing := range c.Links {
+		if normalizeLabel(existing.Label) == label {
+			c.Links[idx] = l
+			return
+		}
+	}
+	c.Links = append(c.Links, l)
+}
+
+// References returns the reference-style links used by the preamble
+// and the items of the versions, in order
+func (c *Changelog) References() []Reference {
+	refs := References(c.Preamble)
+	for _, v := range c.Versions {
+		refs = append(refs, v.References()...)
+	}
+	return refs
+}
+
+// References returns the reference-style links used by the items
+func (v *Version) References() []Reference {
+	var refs []Reference
+	for _, change := range v.Changes {
+		for _, item := range change.Items {
+			refs = append(refs, References(item.Text())...)
+		}
+	}
+	return refs
+}
+
+// UsedLinks returns the definitions of c.Links referenced by versions,
+// in the order they are defined
+func (c *Changelog) UsedLinks(versions []*Version) []*LinkDef {
+	var refs []Reference
+	for _, v := range versions {
+		refs = append(refs, v.References()...)
+	}
+	return c.filterLinks(refs, true)
+}
+
+// UnusedLinks returns the definitions of c.Links that aren't referenced
+// by the preamble or the items
+func (c *Changelog) UnusedLinks() []*LinkDef {
+	return c.filterLinks(c.References(), false)
+}
+
+// filterLinks returns the definitions that are (or aren't) referenced
+func (c *Changelog) filterLinks(refs []Reference, referenced bool) []*LinkDef {
+	used := make(map[string]bool)
+	for _, ref := range refs {
+		used[normalizeLabel(ref.Label)] = true
+	}
+
+	var links []*LinkDef
+	for _, l := range c.Links {
+		if used[normalizeLabel(l.Label)] == referenced {
+			links = append(links, l)
+		}
+	}
+	return links
+}
@@ -0,0 +1,73 @@
+package chg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferences(t *testing.T) {
+	text := "Fix [#12], [the docs][docs], [Guide][] and [inline](https://example.com)\n" +
+		"but not `[#13]`, \\[escaped] or\n[def]: https://example.com"
+
+	expected := []Reference{
+		{Label: "#12", Shortcut: true},
+		{Label: "docs"},
+		{Label: "Guide"},
+	}
+	assert.Equal(t, expected, References(text))
+}
+
+func TestChangelogLink(t *testing.T) {
+	c := &Changelog{
+		Versions: []*Version{{Name: "1.0.0", Link: "https://example.com/v1.0.0"}},
+		Links:    []*LinkDef{{Label: "Read  Me", URL: "https://example.com/README"}},
+	}
+
+	assert.Equal(t, c.Links[0], c.Link("read me"))
+	assert.Equal(t, &LinkDef{Label: "1.0.0", URL: "https://example.com/v1.0.0"}, c.Link("1.0.0"))
+	assert.Nil(t, c.Link("unknown"))
+
+	c.AddLink(&LinkDef{Label: "read me", URL: "https://example.com/README.md"})
+	c.AddLink(&LinkDef{Label: "#1", URL: "https://example.com/issues/1"})
+	assert.Equal(t, []*LinkDef{
+		{Label: "read me", URL: "https://example.com/README.md"},
+		{Label: "#1", URL: "https://example.com/issues/1"},
+	}, c.Links)
+}
+
+func TestChangelogUsedLinks(t *testing.T) {
+	v1 := &Version{
+		Name:    "1.0.0",
+		Changes: []*ChangeList{{Type: Fixed, Items: []*Item{{Description: "Crash [#2]"}}}},
+	}
+	v2 := &Version{
+		Name:    "2.0.0",
+		Changes: []*ChangeList{{Type: Added, Items: []*Item{{Description: "Feature [#1]"}}}},
+	}
+	c := &Changelog{
+		Preamble: "See [docs]",
+		Versions: []*Version{v2, v1},
+		Links: []*LinkDef{
+			{Label: "docs", URL: "https://example.com/docs"},
+			{Label: "#1", URL: "https://example.com/issues/1"},
+			{Label: "#2", URL: "https://example.com/issues/2"},
+		},
+	}
+
+	assert.Equal(t, []*LinkDef{c.Links[1]}, c.UsedLinks([]*Version{v2}))
+	assert.Len(t, c.References(), 3)
+	assert.Empty(t, c.UnusedLinks())
+
+	v1.Changes[0].Items[0].Description = "Crash"
+	assert.Equal(t, []*LinkDef{c.Links[2]}, c.UnusedLinks())
+}
+
+func TestLinkDefRender(t *testing.T) {
+	var buf bytes.Buffer
+	(&LinkDef{Label: "#1", URL: "https://example.com/1"}).Render(&buf)
+	(&LinkDef{Label: "docs", URL: "https://example.com/docs", Title: "Docs"}).Render(&buf)
+
+	assert.Equal(t, "[#1]: https://example.com/1\n[docs]: https://example.com/docs \"Docs\"\n", buf.String())
+}
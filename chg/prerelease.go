@@ -0,0 +1,124 @@
+package chg
+
+import "fmt"
+
+// LatestStable returns the newest release that isn't a pre-release, or
+// nil if there is none. Versions not following semantic versioning are
+// stable.
+func (c *Changelog) LatestStable() *Version {
+	for _, v := range c.Versions {
+		if v.IsUnreleased() {
+			continue
+		}
+		if semver, err := ParseSemVer(v.Name); err != nil || !semver.IsPrerelease() {
+			return v
+		}
+	}
+	return nil
+}
+
+// Prereleases returns the pre-releases of the final version, eg.
+// 2.0.0-rc.1 of 2.0.0, newest first
+func (c *Changelog) Prereleases(version string) []*Version {
+	final, err := ParseSemVer(version)
+	if err != nil || final.IsPrerelease() {
+		return nil
+	}
+
+	var prereleases []*Version
+	for _, v := range c.Versions {
+		semver, err := ParseSemVer(v.Name)
+		if err == nil && semver.IsPrerelease() && semver.Next(BumpNone).Compare(final) == 0 {
+			prereleases = append(prereleases, v)
+		}
+	}
+	return prereleases
+}
+
+// Promote gathers the items of the pre-releases of a final version
+// into it, without duplicates, and removes them. With keep, they are
+// kept and marked as superseded instead. It returns the pre-releases.
+//
+// When they are removed, the compare link of the version starts at the
+// version before the first pre-release.
+func (c *Changelog) Promote(version string, keep bool) ([]*Version, error) {
+	return c.PromoteWithTagPrefix(version, keep, "")
+}
+
+// PromoteWithTagPrefix works like Promote for projects where the tags
+// are prefixed with tagPrefix, see ReleaseWithTagPrefix
+func (c *Changelog) PromoteWithTagPrefix(version string, keep bool, tagPrefix string) ([]*Version, error) {
+	final := c.Version(version)
+	if final == nil {
+		return nil, fmt.Errorf("Unknown version: '%s'", version)
+	}
+	if semver, err := ParseSemVer(final.Name); err != nil || semver.IsPrerelease() || final.IsUnreleased() {
+		return nil, fmt.Errorf("'%s' isn't a final version", version)
+	}
+
+	prereleases := c.Prereleases(final.Name)
+
+	seen := map[string]bool{}
+	for _, cl := range final.Changes {
+		for _, item := range cl.Items {
+			seen[itemKey(cl.Type, item)] = true
+		}
+	}
+	for _, v := range prereleases {
+		for _, cl := range v.Changes {
+			target := final.Change(cl.Type)
+			for _, item := range cl.Items {
+				key := itemKey(cl.Type, item)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				if target == nil {
+					target = &ChangeList{Type: cl.Type}
+					final.Changes = append(final.Changes, target)
+				}
+				copied := *item
+				target.Items = append(target.Items, &copied)
+			}
+		}
+	}
+
+	if keep {
+		for _, v := range prereleases {
+			v.Superseded = true
+		}
+		return prereleases, nil
+	}
+
+	if len(prereleases) > 0 {
+		first := prereleases[len(prereleases)-1]
+		if first.Link != "" {
+			final.Link = replaceTag(first.Link, tagPrefix+first.Name, tagPrefix+final.Name)
+		}
+	}
+	c.removeVersions(prereleases)
+
+	return prereleases, nil
+}
+
+// removeVersions removes the versions from the changelog
+func (c *Changelog) removeVersions(versions []*Version) {
+	removed := map[*Version]bool{}
+	for _, v := range versions {
+		removed[v] = true
+	}
+
+	kept := c.Versions[:0]
+	for _, v := range c.Versions {
+		if !removed[v] {
+			kept = append(kept, v)
+		}
+	}
+	c.Versions = kept
+}
+
+// itemKey identifies an item regardless of line wrapping
+func itemKey(ct ChangeType, item *Item) string {
+	return ct.Name() + "\n" + normalizeText(item.Text())
+}
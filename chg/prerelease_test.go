@@ -0,0 +1,125 @@
+package chg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPrereleaseChangelog() *Changelog {
+	return &Changelog{
+		Versions: []*Version{
+			{Name: "Unreleased", Link: "https://example.com/compare/2.0.0...HEAD"},
+			{
+				Name: "2.0.0",
+				Date: "2020-03-01",
+				Link: "https://example.com/compare/2.0.0-rc.2...2.0.0",
+				Changes: []*ChangeList{
+					{Type: Fixed, Items: []*Item{{Description: "Last bug"}}},
+				},
+			},
+			{
+				Name: "2.0.0-rc.2",
+				Date: "2020-02-15",
+				Link: "https://example.com/compare/2.0.0-rc.1...2.0.0-rc.2",
+				Changes: []*ChangeList{
+					{Type: Fixed, Items: []*Item{{Description: "Bug in the\nfeature"}}},
+					{Type: Added, Items: []*Item{{Description: "Feature"}}},
+				},
+			},
+			{
+				Name: "2.0.0-rc.1",
+				Date: "2020-02-01",
+				Link: "https://example.com/compare/1.0.0...2.0.0-rc.1",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{{Description: "Feature"}, {Description: "Option"}}},
+					{Type: Fixed, Items: []*Item{{Description: "Bug in the feature"}}},
+				},
+			},
+			{Name: "1.0.0", Date: "2020-01-01", Link: "https://example.com/releases/tag/1.0.0"},
+		},
+	}
+}
+
+func TestChangelogLatestStable(t *testing.T) {
+	c := newPrereleaseChangelog()
+	assert.Equal(t, "2.0.0", c.LatestStable().Name)
+
+	c.Versions = append(c.Versions[:1], c.Versions[2:]...)
+	assert.Equal(t, "1.0.0", c.LatestStable().Name)
+
+	c.Versions = c.Versions[:3]
+	assert.Nil(t, c.LatestStable())
+}
+
+func TestChangelogPrereleases(t *testing.T) {
+	c := newPrereleaseChangelog()
+
+	assert.Equal(t, []*Version{c.Versions[2], c.Versions[3]}, c.Prereleases("2.0.0"))
+	assert.Empty(t, c.Prereleases("1.0.0"))
+	assert.Empty(t, c.Prereleases("2.0.0-rc.2"))
+}
+
+func TestChangelogPromote(t *testing.T) {
+	c := newPrereleaseChangelog()
+
+	prereleases, err := c.Promote("2.0.0", false)
+
+	assert.Nil(t, err)
+	assert.Len(t, prereleases, 2)
+	assert.Equal(t, []string{"Unreleased", "2.0.0", "1.0.0"}, versionNames(c))
+
+	final := c.Version("2.0.0")
+	assert.Equal(t, "https://example.com/compare/1.0.0...2.0.0", final.Link)
+	assert.Equal(t, "2020-03-01", final.Date)
+	assert.Equal(t, []*ChangeList{
+		{Type: Fixed, Items: []*Item{{Description: "Last bug"}, {Description: "Bug in the\nfeature"}}},
+		{Type: Added, Items: []*Item{{Description: "Feature"}, {Description: "Option"}}},
+	}, final.Changes)
+}
+
+func TestChangelogPromoteKeep(t *testing.T) {
+	c := newPrereleaseChangelog()
+
+	_, err := c.Promote("2.0.0", true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Unreleased", "2.0.0", "2.0.0-rc.2", "2.0.0-rc.1", "1.0.0"}, versionNames(c))
+	assert.True(t, c.Version("2.0.0-rc.1").Superseded)
+	assert.False(t, c.Version("2.0.0").Superseded)
+	assert.Equal(t, "https://example.com/compare/2.0.0-rc.2...2.0.0", c.Version("2.0.0").Link)
+	assert.Len(t, c.Version("2.0.0").Change(Added).Items, 2)
+	assert.Len(t, c.Version("2.0.0-rc.1").Change(Added).Items, 2)
+}
+
+func TestChangelogPromoteErrors(t *testing.T) {
+	c := newPrereleaseChangelog()
+
+	_, err := c.Promote("3.0.0", false)
+	assert.EqualError(t, err, "Unknown version: '3.0.0'")
+
+	_, err = c.Promote("2.0.0-rc.2", false)
+	assert.EqualError(t, err, "'2.0.0-rc.2' isn't a final version")
+
+	prereleases, err := c.Promote("1.0.0", false)
+	assert.Nil(t, err)
+	assert.Empty(t, prereleases)
+}
+
+func TestVersionRenderSuperseded(t *testing.T) {
+	v := &Version{Name: "2.0.0-rc.1", Date: "2020-02-01", Superseded: true}
+
+	var buf bytes.Buffer
+	v.RenderTitle(&buf)
+
+	assert.Equal(t, "## 2.0.0-rc.1 - 2020-02-01 [SUPERSEDED]", buf.String())
+}
+
+func versionNames(c *Changelog) []string {
+	var names []string
+	for _, v := range c.Versions {
+		names = append(names, v.Name)
+	}
+	return names
+}
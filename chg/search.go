@@ -0,0 +1,253 @@
+package chg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SearchMode tells how the text of a query matches the items
+type SearchMode string
+
+// Search modes
+const (
+	// SearchSubstring matches items containing the text, ignoring case
+	SearchSubstring SearchMode = "substring"
+	// SearchRegex matches items with the regular expression
+	SearchRegex SearchMode = "regex"
+	// SearchFuzzy matches items with words similar to every word of the
+	// text, tolerating typos
+	SearchFuzzy SearchMode = "fuzzy"
+)
+
+// Query selects items of a changelog. The zero value matches all of
+// them.
+type Query struct {
+	Text string
+	Mode SearchMode // SearchSubstring when empty
+
+	Types []ChangeType // Any of the types
+
+	// Versions newer than From up to To, like in Changelog.Between
+	From string
+	To   string
+
+	// Release dates, inclusive. Versions without a valid date, like
+	// Unreleased, don't match when any of them is set.
+	Since time.Time
+	Until time.Time
+
+	Issue  string // Issue reference, eg. "12", "#12" or "org/repo#12"
+	Author string // Mentioned user, eg. "octocat" or "@octocat"
+}
+
+// Match is an item found by Search
+type Match struct {
+	Version string `json:"version"`
+	Date    string `json:"date,omitempty"`
+	Type    string `json:"type"`
+	Item    string `json:"item"` // Markdown of the item, including body and children
+}
+
+func (m Match) String() string {
+	version := m.Version
+	if m.Date != "" {
+		version = fmt.Sprintf("%s (%s)", m.Version, m.Date)
+	}
+	return fmt.Sprintf("%s: %s: %s", version, m.Type, normalizeText(m.Item))
+}
+
+// Search returns the items matching the query, newest version first
+func (c *Changelog) Search(q Query) ([]Match, error) {
+	versions, err := c.Between(q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := q.filters()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Match
+	for _, v := range versions {
+		if !q.matchDate(v) {
+			continue
+		}
+		for _, ct := range q.types(v) {
+			for _, item := range v.Change(ct).Items {
+				text := item.Text()
+				if matchAll(filters, text) {
+					matches = append(matches, Match{Version: v.Name, Date: v.Date, Type: ct.String(), Item: text})
+				}
+			}
+		}
+	}
+	return matches, nil
+}
+
+// types returns the change types of the version selected by the query,
+// in the configured order
+func (q Query) types(v *Version) []ChangeType {
+	var types []ChangeType
+	for _, ct := range ChangeTypes() {
+		if v.Change(ct) == nil {
+			continue
+		}
+		if len(q.Types) == 0 || containsType(q.Types, ct) {
+			types = append(types, ct)
+		}
+	}
+	return types
+}
+
+func containsType(types []ChangeType, ct ChangeType) bool {
+	for _, t := range types {
+		if t == ct {
+			return true
+		}
+	}
+	return false
+}
+
+func (q Query) matchDate(v *Version) bool {
+	if q.Since.IsZero() && q.Until.IsZero() {
+		return true
+	}
+
+	t, err := v.ReleaseTime()
+	if err != nil {
+		return false
+	}
+	if !q.Since.IsZero() && t.Before(q.Since) {
+		return false
+	}
+	return q.Until.IsZero() || !t.After(q.Until)
+}
+
+// filters returns the functions an item text has to match
+func (q Query) filters() ([]func(string) bool, error) {
+	var filters []func(string) bool
+
+	if q.Text != "" {
+		switch q.Mode {
+		case SearchSubstring, "":
+			text := strings.ToLower(q.Text)
+			filters = append(filters, func(s string) bool {
+				return strings.Contains(strings.ToLower(s), text)
+			})
+		case SearchRegex:
+			re, err := regexp.Compile(q.Text)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid regular expression '%s': %s", q.Text, err)
+			}
+			filters = append(filters, re.MatchString)
+		case SearchFuzzy:
+			filters = append(filters, func(s string) bool {
+				return fuzzyMatch(q.Text, s)
+			})
+		default:
+			return nil, fmt.Errorf("Unknown search mode: '%s'", q.Mode)
+		}
+	}
+
+	if issue := strings.TrimPrefix(strings.TrimSpace(q.Issue), "#"); issue != "" {
+		filters = append(filters, issuePattern(issue).MatchString)
+	}
+
+	if author := strings.TrimPrefix(strings.TrimSpace(q.Author), "@"); author != "" {
+		re := regexp.MustCompile(`(?i)@` + regexp.QuoteMeta(author) + `($|[^\w-])`)
+		filters = append(filters, re.MatchString)
+	}
+
+	return filters, nil
+}
+
+func matchAll(filters []func(string) bool, text string) bool {
+	for _, f := range filters {
+		if !f(text) {
+			return false
+		}
+	}
+	return true
+}
+
+var reIssueNumber = regexp.MustCompile(`^\d+$`)
+
+// issuePattern matches references to the issue. Numbers match "#12" and
+// links to issues, pull and merge requests; other references, like
+// "org/repo#12" or "JIRA-12", match as written.
+func issuePattern(issue string) *regexp.Regexp {
+	if reIssueNumber.MatchString(issue) {
+		return regexp.MustCompile(`(#|/issues/|/pull/|/merge_requests/)` + issue + `\b`)
+	}
+	return regexp.MustCompile(`(?i)(^|[^\w/])` + regexp.QuoteMeta(issue) + `\b`)
+}
+
+// fuzzyMatch checks if every word of query is similar to a word of
+// text: a prefix of it, or a few typos away
+func fuzzyMatch(query, text string) bool {
+	words := searchWords(text)
+
+	for _, q := range searchWords(query) {
+		found := false
+		for _, w := range words {
+			if strings.HasPrefix(w, q) || editDistance(q, w) <= len(q)/4 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// searchWords splits the text in lowercase words
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// editDistance returns the number of insertions, deletions,
+// substitutions and transpositions of adjacent letters to turn a into b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
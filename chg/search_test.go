@@ -0,0 +1,109 @@
+package chg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSearchChangelog() *Changelog {
+	return &Changelog{
+		Versions: []*Version{
+			{
+				Name: "Unreleased",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{{Description: "Search command by @octocat"}}},
+				},
+			},
+			{
+				Name: "1.1.0",
+				Date: "2020-02-01",
+				Changes: []*ChangeList{
+					{Type: Fixed, Items: []*Item{
+						{Description: "Crash on empty files (#12)"},
+						{Description: "Wrong dates", Children: []*Item{{Description: "In JSON output"}}},
+					}},
+				},
+			},
+			{
+				Name: "1.0.0",
+				Date: "2020-01-01",
+				Changes: []*ChangeList{
+					{Type: Added, Items: []*Item{{Description: "JSON output ([#123](https://github.com/o/r/pull/123))"}}},
+					{Type: Fixed, Items: []*Item{{Description: "Crash on startup by @octo"}}},
+				},
+			},
+		},
+	}
+}
+
+func searchItems(t *testing.T, q Query) []string {
+	matches, err := newSearchChangelog().Search(q)
+	assert.Nil(t, err)
+
+	var items []string
+	for _, m := range matches {
+		items = append(items, m.Version+": "+m.Item)
+	}
+	return items
+}
+
+func TestSearch(t *testing.T) {
+	assert.Equal(t, []string{
+		"1.1.0: Crash on empty files (#12)",
+		"1.0.0: Crash on startup by @octo",
+	}, searchItems(t, Query{Text: "crash"}))
+
+	assert.Equal(t, []string{
+		"1.1.0: Wrong dates\n- In JSON output",
+		"1.0.0: JSON output ([#123](https://github.com/o/r/pull/123))",
+	}, searchItems(t, Query{Text: "json"}))
+
+	assert.Len(t, searchItems(t, Query{}), 5)
+}
+
+func TestSearchRegex(t *testing.T) {
+	assert.Equal(t, []string{"1.0.0: Crash on startup by @octo"}, searchItems(t, Query{Text: `^Crash.*up\b`, Mode: SearchRegex}))
+
+	_, err := newSearchChangelog().Search(Query{Text: "(", Mode: SearchRegex})
+	assert.Contains(t, err.Error(), "Invalid regular expression '('")
+}
+
+func TestSearchFuzzy(t *testing.T) {
+	assert.Equal(t, []string{"1.1.0: Crash on empty files (#12)"}, searchItems(t, Query{Text: "crahs empty", Mode: SearchFuzzy}))
+	assert.Equal(t, []string{"Unreleased: Search command by @octocat"}, searchItems(t, Query{Text: "serch comand", Mode: SearchFuzzy}))
+	assert.Empty(t, searchItems(t, Query{Text: "crash upload", Mode: SearchFuzzy}))
+}
+
+func TestSearchFilters(t *testing.T) {
+	assert.Equal(t, []string{
+		"Unreleased: Search command by @octocat",
+		"1.0.0: JSON output ([#123](https://github.com/o/r/pull/123))",
+	}, searchItems(t, Query{Types: []ChangeType{Added}}))
+
+	assert.Equal(t, []string{
+		"1.1.0: Crash on empty files (#12)",
+		"1.1.0: Wrong dates\n- In JSON output",
+	}, searchItems(t, Query{From: "1.0.0", To: "1.1.0"}))
+
+	since, _ := ParseDate("2020-01-15")
+	assert.Len(t, searchItems(t, Query{Since: since}), 2)
+	assert.Len(t, searchItems(t, Query{Until: since}), 2)
+	assert.Len(t, searchItems(t, Query{Since: since, Until: since.Add(24 * time.Hour)}), 0)
+
+	_, err := newSearchChangelog().Search(Query{From: "0.1.0"})
+	assert.EqualError(t, err, "Unknown version: '0.1.0'")
+}
+
+func TestSearchMetadata(t *testing.T) {
+	assert.Equal(t, []string{"1.1.0: Crash on empty files (#12)"}, searchItems(t, Query{Issue: "#12"}))
+	assert.Equal(t, []string{"1.0.0: JSON output ([#123](https://github.com/o/r/pull/123))"}, searchItems(t, Query{Issue: "123"}))
+	assert.Equal(t, []string{"1.0.0: Crash on startup by @octo"}, searchItems(t, Query{Author: "@octo"}))
+	assert.Equal(t, []string{"Unreleased: Search command by @octocat"}, searchItems(t, Query{Author: "OctoCat", Text: "search"}))
+}
+
+func TestMatchString(t *testing.T) {
+	assert.Equal(t, "1.0.0 (2020-01-01): Fixed: A long item wrapped", Match{Version: "1.0.0", Date: "2020-01-01", Type: "Fixed", Item: "A long\nitem wrapped"}.String())
+	assert.Equal(t, "Unreleased: Added: Item", Match{Version: "Unreleased", Type: "Added", Item: "Item"}.String())
+}
@@ -0,0 +1,201 @@
+package chg
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var reSemVer = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z\-.]+))?(?:\+([0-9A-Za-z\-.]+))?$`)
+
+// SemVer is a version following https://semver.org, optionally with a
+// "v" prefix
+type SemVer struct {
+	Prefix     string // "v" or empty
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string // eg. "rc.1"
+	Build      string
+}
+
+// ParseSemVer parses a semantic version, eg. 1.2.3, v1.2.3 or 1.2.3-rc.1
+func ParseSemVer(version string) (SemVer, error) {
+	m := reSemVer.FindStringSubmatch(strings.TrimSpace(version))
+	if m == nil {
+		return SemVer{}, fmt.Errorf("invalid semantic version '%s'", version)
+	}
+
+	major, _ := strconv.Atoi(m[2])
+	minor, _ := strconv.Atoi(m[3])
+	patch, _ := strconv.Atoi(m[4])
+	return SemVer{Prefix: m[1], Major: major, Minor: minor, Patch: patch, Prerelease: m[5], Build: m[6]}, nil
+}
+
+func (v SemVer) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// IsPrerelease tells if the version is a pre-release, eg. 1.0.0-beta.2
+func (v SemVer) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Compare returns -1, 0 or 1 when v precedes, equals or follows o.
+// Pre-releases precede the version and build metadata is ignored.
+func (v SemVer) Compare(o SemVer) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(o.Prerelease, ".")
+	for idx := 0; idx < len(a) && idx < len(b); idx++ {
+		if c := comparePrereleaseID(a[idx], b[idx]); c != 0 {
+			return c
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+// comparePrereleaseID compares numeric identifiers numerically, and
+// before the alphanumeric ones, which are compared in ASCII order
+func comparePrereleaseID(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return sign(na - nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// Bump is the part of a version incremented by a release
+type Bump int
+
+// Bumps, from the smallest
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	return [...]string{"none", "patch", "minor", "major"}[b]
+}
+
+// Next returns the version incremented by the bump. Pre-releases
+// become the version they precede, eg. 2.0.0-rc.1 becomes 2.0.0.
+// Before 1.0.0, major bumps increment the minor version.
+func (v SemVer) Next(b Bump) SemVer {
+	next := SemVer{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if v.IsPrerelease() || b == BumpNone {
+		return next
+	}
+
+	if b == BumpMajor && v.Major == 0 {
+		b = BumpMinor
+	}
+
+	switch b {
+	case BumpMajor:
+		next.Major, next.Minor, next.Patch = v.Major+1, 0, 0
+	case BumpMinor:
+		next.Minor, next.Patch = v.Minor+1, 0
+	case BumpPatch:
+		next.Patch++
+	}
+	return next
+}
+
+// bumps of the change types; the configured ones not listed are patches
+var changeTypeBumps = map[ChangeType]Bump{
+	Removed:    BumpMajor,
+	Added:      BumpMinor,
+	Changed:    BumpMinor,
+	Deprecated: BumpMinor,
+	Fixed:      BumpPatch,
+	Security:   BumpPatch,
+}
+
+var reBreaking = regexp.MustCompile(`(?i)^\W*breaking\b`)
+
+// Bump returns the bump required by the changes of the version: major
+// for removals and items starting with "BREAKING", minor for additions,
+// changes and deprecations and patch for the others
+func (v *Version) Bump() Bump {
+	bump := BumpNone
+	for _, cl := range v.Changes {
+		for _, item := range cl.Items {
+			b, ok := changeTypeBumps[cl.Type]
+			if !ok {
+				b = BumpPatch
+			}
+			if reBreaking.MatchString(item.Description) {
+				b = BumpMajor
+			}
+			if b > bump {
+				bump = b
+			}
+		}
+	}
+	return bump
+}
+
+// NextVersion suggests the version of the Unreleased changes, from the
+// latest release and Version.Bump. It's 0.1.0 for the first release and
+// empty when there are no changes or the latest release doesn't follow
+// semantic versioning.
+func (c *Changelog) NextVersion() string {
+	unreleased := c.Version("Unreleased")
+	if unreleased == nil {
+		return ""
+	}
+	bump := unreleased.Bump()
+	if bump == BumpNone {
+		return ""
+	}
+
+	latest := c.Latest()
+	if latest == nil {
+		return "0.1.0"
+	}
+	current, err := ParseSemVer(latest.Name)
+	if err != nil {
+		return ""
+	}
+	return current.Next(bump).String()
+}
@@ -0,0 +1,99 @@
+package chg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSemVer(t *testing.T) {
+	v, err := ParseSemVer("v1.2.3-rc.1+build.5")
+
+	assert.Nil(t, err)
+	assert.Equal(t, SemVer{Prefix: "v", Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1", Build: "build.5"}, v)
+	assert.Equal(t, "v1.2.3-rc.1+build.5", v.String())
+	assert.True(t, v.IsPrerelease())
+
+	for _, invalid := range []string{"1.2", "01.2.3", "1.2.3-", "Unreleased"} {
+		_, err := ParseSemVer(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestSemVerCompare(t *testing.T) {
+	// In ascending order, from semver.org
+	versions := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "v1.0.1", "1.1.0", "2.0.0",
+	}
+
+	for i := range versions {
+		for j := range versions {
+			a, _ := ParseSemVer(versions[i])
+			b, _ := ParseSemVer(versions[j])
+			assert.Equal(t, sign(i-j), a.Compare(b), "%s and %s", versions[i], versions[j])
+		}
+	}
+
+	a, _ := ParseSemVer("1.0.0+build.1")
+	b, _ := ParseSemVer("1.0.0+build.2")
+	assert.Equal(t, 0, a.Compare(b))
+}
+
+func TestSemVerNext(t *testing.T) {
+	tests := []struct {
+		version  string
+		bump     Bump
+		expected string
+	}{
+		{"1.2.3", BumpMajor, "2.0.0"},
+		{"1.2.3", BumpMinor, "1.3.0"},
+		{"v1.2.3", BumpPatch, "v1.2.4"},
+		{"0.2.3", BumpMajor, "0.3.0"},
+		{"2.0.0-rc.1", BumpPatch, "2.0.0"},
+		{"1.2.3+build", BumpNone, "1.2.3"},
+	}
+
+	for _, test := range tests {
+		v, _ := ParseSemVer(test.version)
+		assert.Equal(t, test.expected, v.Next(test.bump).String(), test.version)
+	}
+}
+
+func TestVersionBump(t *testing.T) {
+	tests := []struct {
+		changes  []*ChangeList
+		expected Bump
+	}{
+		{nil, BumpNone},
+		{[]*ChangeList{{Type: Fixed, Items: []*Item{{Description: "Bug"}}}}, BumpPatch},
+		{[]*ChangeList{{Type: Fixed, Items: []*Item{{Description: "Bug"}}}, {Type: Added, Items: []*Item{{Description: "Feature"}}}}, BumpMinor},
+		{[]*ChangeList{{Type: Removed, Items: []*Item{{Description: "Old API"}}}}, BumpMajor},
+		{[]*ChangeList{{Type: Changed, Items: []*Item{{Description: "**BREAKING**: new config format"}}}}, BumpMajor},
+		{[]*ChangeList{{Type: Added}}, BumpNone},
+	}
+
+	for _, test := range tests {
+		v := &Version{Name: "Unreleased", Changes: test.changes}
+		assert.Equal(t, test.expected, v.Bump())
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	c := &Changelog{
+		Versions: []*Version{
+			{Name: "Unreleased", Changes: []*ChangeList{{Type: Added, Items: []*Item{{Description: "Feature"}}}}},
+			{Name: "1.2.3", Date: "2020-01-01"},
+		},
+	}
+	assert.Equal(t, "1.3.0", c.NextVersion())
+
+	c.Versions[1].Name = "2020.01"
+	assert.Equal(t, "", c.NextVersion())
+
+	c.Versions = c.Versions[:1]
+	assert.Equal(t, "0.1.0", c.NextVersion())
+
+	c.Versions[0].Changes = nil
+	assert.Equal(t, "", c.NextVersion())
+}
@@ -0,0 +1,222 @@
+package chg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Stats summarizes the releases and the items of a changelog
+type Stats struct {
+	Releases   int     `json:"releases"`
+	Yanked     int     `json:"yanked"`
+	YankedRate float64 `json:"yankedRate"` // Share of the releases, from 0 to 1
+
+	// Average interval between the dated releases
+	AverageDays float64 `json:"averageDaysBetweenReleases"`
+
+	// Releases of each month and quarter, from the first to the last
+	// dated release, including the periods without releases
+	Months   []PeriodStats `json:"releasesPerMonth"`
+	Quarters []PeriodStats `json:"releasesPerQuarter"`
+
+	Items    int            `json:"items"`
+	Types    []TypeStats    `json:"types"`
+	Versions []VersionStats `json:"versions"` // Newest first, Unreleased included
+
+	// Longest time changes waited in Unreleased, nil without dated
+	// releases
+	LongestUnreleased *UnreleasedPeriod `json:"longestUnreleased,omitempty"`
+}
+
+// PeriodStats is the number of releases in a month (2024-01) or
+// quarter (2024-Q1)
+type PeriodStats struct {
+	Period   string `json:"period"`
+	Releases int    `json:"releases"`
+}
+
+// TypeStats is the number of items of a change type in all versions
+type TypeStats struct {
+	Type  string  `json:"type"`
+	Items int     `json:"items"`
+	Share float64 `json:"share"` // Share of all the items, from 0 to 1
+}
+
+// VersionStats is the number of items of a version per change type
+type VersionStats struct {
+	Version string         `json:"version"`
+	Date    string         `json:"date,omitempty"`
+	Yanked  bool           `json:"yanked"`
+	Items   int            `json:"items"`
+	Types   map[string]int `json:"types"`
+
+	// Days since the previous release, when both are dated
+	DaysSincePrevious *int `json:"daysSincePrevious,omitempty"`
+}
+
+// UnreleasedPeriod is the time between a release and the next one, or
+// until now for changes still in Unreleased
+type UnreleasedPeriod struct {
+	Version string `json:"version"` // Release that ended the period, or Unreleased
+	Since   string `json:"since"`
+	Until   string `json:"until"`
+	Days    int    `json:"days"`
+}
+
+func (p UnreleasedPeriod) String() string {
+	return fmt.Sprintf("%d days, %s to %s (%s)", p.Days, p.Since, p.Until, p.Version)
+}
+
+// datedRelease is a release with a valid date
+type datedRelease struct {
+	version *Version
+	date    time.Time
+}
+
+// Stats computes the statistics of the changelog. now ends the period
+// of the changes in Unreleased.
+func (c *Changelog) Stats(now time.Time) Stats {
+	var stats Stats
+	var dated []datedRelease
+	typeItems := map[ChangeType]int{}
+
+	for _, v := range c.Versions {
+		vs := VersionStats{Version: v.Name, Date: v.Date, Yanked: v.Yanked, Types: map[string]int{}}
+		for _, cl := range v.Changes {
+			vs.Types[cl.Type.String()] += len(cl.Items)
+			vs.Items += len(cl.Items)
+			typeItems[cl.Type] += len(cl.Items)
+		}
+		stats.Items += vs.Items
+		stats.Versions = append(stats.Versions, vs)
+
+		if v.IsUnreleased() {
+			continue
+		}
+		stats.Releases++
+		if v.Yanked {
+			stats.Yanked++
+		}
+		if t, err := v.ReleaseTime(); err == nil {
+			dated = append(dated, datedRelease{v, t})
+		}
+	}
+
+	for _, ct := range ChangeTypes() {
+		ts := TypeStats{Type: ct.String(), Items: typeItems[ct]}
+		if stats.Items > 0 {
+			ts.Share = round(float64(ts.Items)/float64(stats.Items), 4)
+		}
+		stats.Types = append(stats.Types, ts)
+	}
+
+	if stats.Releases > 0 {
+		stats.YankedRate = round(float64(stats.Yanked)/float64(stats.Releases), 4)
+	}
+
+	sort.SliceStable(dated, func(i, j int) bool {
+		return dated[i].date.Before(dated[j].date)
+	})
+	stats.countPeriods(dated)
+	stats.measureIntervals(c, dated, now)
+
+	return stats
+}
+
+// countPeriods counts the releases per month and quarter
+func (s *Stats) countPeriods(dated []datedRelease) {
+	if len(dated) == 0 {
+		return
+	}
+
+	first, last := dated[0].date, dated[len(dated)-1].date
+	months := map[string]int{}
+	quarters := map[string]int{}
+	for _, r := range dated {
+		months[monthOf(r.date)]++
+		quarters[quarterOf(r.date)]++
+	}
+
+	for t := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC); !t.After(last); t = t.AddDate(0, 1, 0) {
+		s.Months = append(s.Months, PeriodStats{monthOf(t), months[monthOf(t)]})
+		if t.Month()%3 == 1 || len(s.Quarters) == 0 {
+			s.Quarters = append(s.Quarters, PeriodStats{quarterOf(t), quarters[quarterOf(t)]})
+		}
+	}
+}
+
+func monthOf(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+func quarterOf(t time.Time) string {
+	return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())+2)/3)
+}
+
+// measureIntervals computes the days between the releases, their
+// average and the longest one, including the current Unreleased
+func (s *Stats) measureIntervals(c *Changelog, dated []datedRelease, now time.Time) {
+	if len(dated) == 0 {
+		return
+	}
+
+	var longest *UnreleasedPeriod
+	for idx := 1; idx < len(dated); idx++ {
+		prev, cur := dated[idx-1], dated[idx]
+		days := daysBetween(prev.date, cur.date)
+		s.version(cur.version.Name).DaysSincePrevious = &days
+
+		if longest == nil || days > longest.Days {
+			longest = &UnreleasedPeriod{
+				Version: cur.version.Name,
+				Since:   prev.date.Format(ISODate),
+				Until:   cur.date.Format(ISODate),
+				Days:    days,
+			}
+		}
+	}
+
+	if len(dated) > 1 {
+		first, last := dated[0].date, dated[len(dated)-1].date
+		s.AverageDays = round(float64(daysBetween(first, last))/float64(len(dated)-1), 1)
+	}
+
+	if v := c.Version("Unreleased"); v != nil && len(v.Changes) > 0 {
+		latest := dated[len(dated)-1].date
+		days := daysBetween(latest, now)
+		if longest == nil || days > longest.Days {
+			longest = &UnreleasedPeriod{
+				Version: v.Name,
+				Since:   latest.Format(ISODate),
+				Until:   now.Format(ISODate),
+				Days:    days,
+			}
+		}
+	}
+
+	s.LongestUnreleased = longest
+}
+
+// version returns the stats of the version
+func (s *Stats) version(name string) *VersionStats {
+	for idx := range s.Versions {
+		if s.Versions[idx].Version == name {
+			return &s.Versions[idx]
+		}
+	}
+	return nil
+}
+
+// daysBetween returns the number of calendar days from a to b
+func daysBetween(a, b time.Time) int {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
+
+func round(value float64, places int) float64 {
+	pow := math.Pow(10, float64(places))
+	return math.Round(value*pow) / pow
+}
@@ -0,0 +1,85 @@
+package chg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStatsChangelog() *Changelog {
+	items := func(n int) []*Item {
+		var items []*Item
+		for i := 0; i < n; i++ {
+			items = append(items, &Item{Description: "Item"})
+		}
+		return items
+	}
+
+	return &Changelog{
+		Versions: []*Version{
+			{Name: "Unreleased", Changes: []*ChangeList{{Type: Added, Items: items(1)}}},
+			{Name: "1.2.0", Date: "2020-04-10", Changes: []*ChangeList{{Type: Security, Items: items(1)}}},
+			{Name: "1.1.0", Date: "2020-01-31", Yanked: true, Changes: []*ChangeList{{Type: Fixed, Items: items(2)}}},
+			{Name: "1.0.0", Date: "2020-01-01", Changes: []*ChangeList{
+				{Type: Added, Items: items(3)},
+				{Type: Fixed, Items: items(1)},
+			}},
+		},
+	}
+}
+
+func TestStats(t *testing.T) {
+	now := time.Date(2020, 5, 1, 15, 0, 0, 0, time.UTC)
+
+	stats := newStatsChangelog().Stats(now)
+
+	assert.Equal(t, 3, stats.Releases)
+	assert.Equal(t, 1, stats.Yanked)
+	assert.Equal(t, 0.3333, stats.YankedRate)
+	assert.Equal(t, 50.0, stats.AverageDays)
+	assert.Equal(t, 8, stats.Items)
+	assert.Equal(t, []PeriodStats{
+		{"2020-01", 2}, {"2020-02", 0}, {"2020-03", 0}, {"2020-04", 1},
+	}, stats.Months)
+	assert.Equal(t, []PeriodStats{{"2020-Q1", 2}, {"2020-Q2", 1}}, stats.Quarters)
+	assert.Equal(t, []TypeStats{
+		{"Added", 4, 0.5},
+		{"Changed", 0, 0},
+		{"Deprecated", 0, 0},
+		{"Fixed", 3, 0.375},
+		{"Removed", 0, 0},
+		{"Security", 1, 0.125},
+	}, stats.Types)
+	assert.Equal(t, &UnreleasedPeriod{Version: "1.2.0", Since: "2020-01-31", Until: "2020-04-10", Days: 70}, stats.LongestUnreleased)
+
+	days := 30
+	assert.Equal(t, VersionStats{
+		Version:           "1.1.0",
+		Date:              "2020-01-31",
+		Yanked:            true,
+		Items:             2,
+		Types:             map[string]int{"Fixed": 2},
+		DaysSincePrevious: &days,
+	}, stats.Versions[2])
+	assert.Nil(t, stats.Versions[3].DaysSincePrevious)
+}
+
+func TestStatsUnreleasedPeriod(t *testing.T) {
+	now := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	stats := newStatsChangelog().Stats(now)
+
+	assert.Equal(t, &UnreleasedPeriod{Version: "Unreleased", Since: "2020-04-10", Until: "2020-07-01", Days: 82}, stats.LongestUnreleased)
+	assert.Equal(t, "82 days, 2020-04-10 to 2020-07-01 (Unreleased)", stats.LongestUnreleased.String())
+}
+
+func TestStatsEmpty(t *testing.T) {
+	stats := NewEmptyChangelog("").Stats(time.Now())
+
+	assert.Equal(t, 0, stats.Releases)
+	assert.Equal(t, 0.0, stats.YankedRate)
+	assert.Nil(t, stats.Months)
+	assert.Nil(t, stats.LongestUnreleased)
+	assert.Len(t, stats.Versions, 1)
+}
@@ -0,0 +1,230 @@
+package chg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Blank line policies
+const (
+	BlankLinesCompact = "compact" // Headings are followed directly by their content
+	BlankLinesSpaced  = "spaced"  // Headings are surrounded by blank lines
+)
+
+// Link styles of the version headings
+const (
+	LinkStyleReference = "reference" // "## [1.0.0]" with the definitions at the bottom
+	LinkStyleInline    = "inline"    // "## [1.0.0](https://...)"
+)
+
+// Style controls how the changelog markdown is written by Render
+type Style struct {
+	Wrap       int    // Maximum line width of the items, 0 disables wrapping
+	Bullet     string // List marker: "-", "*" or "+"
+	Emphasis   string // Emphasis marker: "_" or "*"
+	BlankLines string // BlankLinesCompact or BlankLinesSpaced
+	LinkStyle  string // LinkStyleReference or LinkStyleInline
+	DateFormat string // Layout of the release dates, as used by time.Format
+}
+
+// DefaultStyle is the style used when none is configured
+var DefaultStyle = Style{
+	Bullet:     "-",
+	Emphasis:   "_",
+	BlankLines: BlankLinesCompact,
+	LinkStyle:  LinkStyleReference,
+	DateFormat: ISODate,
+}
+
+// configured style
+var style = DefaultStyle
+
+var (
+	// reBlockStart matches the start of blocks other than paragraphs
+	reBlockStart = regexp.MustCompile("^(?:[-*+] |[0-9]+[.)] |[>#|]|```|~~~)")
+	// reBlockWord matches words that start a block at the start of a line
+	reBlockWord = regexp.MustCompile("^(?:[-*+]|[0-9]+[.)]|[>#|].*|```.*|~~~.*|=+|-+)$")
+)
+
+// SetStyle replaces the style used by Render. Empty fields use the
+// values of DefaultStyle.
+func SetStyle(s Style) error {
+	s, err := s.Normalize()
+	if err != nil {
+		return err
+	}
+
+	style = s
+	return nil
+}
+
+// CurrentStyle returns the style used by Render
+func CurrentStyle() Style {
+	return style
+}
+
+// Normalize returns the style with the empty fields set to the values
+// of DefaultStyle, or an error when it's invalid
+func (s Style) Normalize() (Style, error) {
+	if s.Bullet == "" {
+		s.Bullet = DefaultStyle.Bullet
+	}
+	if s.Emphasis == "" {
+		s.Emphasis = DefaultStyle.Emphasis
+	}
+	if s.BlankLines == "" {
+		s.BlankLines = DefaultStyle.BlankLines
+	}
+	if s.LinkStyle == "" {
+		s.LinkStyle = DefaultStyle.LinkStyle
+	}
+	if s.DateFormat == "" {
+		s.DateFormat = DefaultStyle.DateFormat
+	}
+
+	switch {
+	case s.Wrap < 0:
+		return s, fmt.Errorf("invalid wrap width %d", s.Wrap)
+	case s.Bullet != "-" && s.Bullet != "*" && s.Bullet != "+":
+		return s, fmt.Errorf("invalid bullet '%s', use '-', '*' or '+'", s.Bullet)
+	case s.Emphasis != "_" && s.Emphasis != "*":
+		return s, fmt.Errorf("invalid emphasis '%s', use '_' or '*'", s.Emphasis)
+	case s.BlankLines != BlankLinesCompact && s.BlankLines != BlankLinesSpaced:
+		return s, fmt.Errorf("invalid blank lines policy '%s', use '%s' or '%s'", s.BlankLines, BlankLinesCompact, BlankLinesSpaced)
+	case s.LinkStyle != LinkStyleReference && s.LinkStyle != LinkStyleInline:
+		return s, fmt.Errorf("invalid link style '%s', use '%s' or '%s'", s.LinkStyle, LinkStyleReference, LinkStyleInline)
+	case !isDateLayout(s.DateFormat):
+		return s, fmt.Errorf("invalid date format '%s', use a layout like '%s'", s.DateFormat, ISODate)
+	}
+	return s, nil
+}
+
+// isDateLayout reports whether the layout keeps the day, month and year
+// of the dates, so they can be parsed back
+func isDateLayout(layout string) bool {
+	ref := time.Date(2001, 12, 31, 0, 0, 0, 0, time.UTC)
+	t, err := time.Parse(layout, ref.Format(layout))
+	return err == nil && t.Equal(ref)
+}
+
+// isParagraph reports whether the markdown block is a plain paragraph,
+// the only kind of block that is wrapped and restyled
+func isParagraph(block string) bool {
+	return block != "" && !startsWithSpace(block) && !reBlockStart.MatchString(block)
+}
+
+func startsWithSpace(s string) bool {
+	return s[0] == ' ' || s[0] == '\t'
+}
+
+// styleParagraph applies the emphasis marker and, when wrapping is
+// enabled, reflows the paragraph in lines of up to width characters
+func (s Style) styleParagraph(text string, width int) string {
+	if s.Emphasis != "_" {
+		text = replaceEmphasis(text, s.Emphasis)
+	}
+	if s.Wrap == 0 {
+		return text
+	}
+	return wrap(text, width)
+}
+
+// wrap reflows the words of text in lines of up to width characters.
+// Words are never split, and a word that would start a new block (eg.
+// "-" or "#") is kept on the previous line.
+func wrap(text string, width int) string {
+	var (
+		buf    strings.Builder
+		length = 0
+	)
+
+	for idx, word := range strings.Fields(text) {
+		wordLength := len([]rune(word))
+		switch {
+		case idx == 0:
+		case length+1+wordLength > width && !reBlockWord.MatchString(word):
+			buf.WriteString("\n")
+			length = 0
+		default:
+			buf.WriteString(" ")
+			length++
+		}
+		buf.WriteString(word)
+		length += wordLength
+	}
+
+	return buf.String()
+}
+
+// replaceEmphasis replaces the "_" emphasis delimiters with marker.
+// Underscores inside words, code spans and link destinations are kept.
+func replaceEmphasis(text, marker string) string {
+	runes := []rune(text)
+
+	var buf strings.Builder
+	for idx := 0; idx < len(runes); idx++ {
+		r := runes[idx]
+		switch {
+		case r == '`':
+			end := closingBackticks(runes, idx)
+			buf.WriteString(string(runes[idx:end]))
+			idx = end - 1
+		case r == '(' && idx > 0 && runes[idx-1] == ']':
+			end := idx
+			for end < len(runes) && runes[end] != ')' {
+				end++
+			}
+			buf.WriteString(string(runes[idx:end]))
+			idx = end - 1
+		case r == '_' && isEmphasisDelimiter(runes, idx):
+			buf.WriteString(marker)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+
+	return buf.String()
+}
+
+// closingBackticks returns the index after the code span starting at
+// idx, or after the backticks when the span isn't closed
+func closingBackticks(runes []rune, idx int) int {
+	n := 0
+	for idx+n < len(runes) && runes[idx+n] == '`' {
+		n++
+	}
+	fence := strings.Repeat("`", n)
+
+	rest := string(runes[idx+n:])
+	if end := strings.Index(rest, fence); end >= 0 {
+		return idx + n + len([]rune(rest[:end])) + n
+	}
+	return idx + n
+}
+
+// isEmphasisDelimiter reports whether the single underscore at idx
+// opens or closes an emphasis
+func isEmphasisDelimiter(runes []rune, idx int) bool {
+	prev, next := ' ', ' '
+	if idx > 0 {
+		prev = runes[idx-1]
+	}
+	if idx+1 < len(runes) {
+		next = runes[idx+1]
+	}
+
+	if prev == '_' || next == '_' || prev == '\\' {
+		return false
+	}
+	if isWordRune(prev) && isWordRune(next) {
+		return false
+	}
+	return !unicode.IsSpace(prev) || !unicode.IsSpace(next)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,122 @@
+package chg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetStyle(t *testing.T) {
+	defer SetStyle(DefaultStyle)
+
+	assert.NoError(t, SetStyle(Style{Bullet: "*"}))
+	assert.Equal(t, Style{
+		Bullet:     "*",
+		Emphasis:   "_",
+		BlankLines: BlankLinesCompact,
+		LinkStyle:  LinkStyleReference,
+		DateFormat: ISODate,
+	}, CurrentStyle())
+
+	assert.EqualError(t, SetStyle(Style{Wrap: -1}), "invalid wrap width -1")
+	assert.EqualError(t, SetStyle(Style{Bullet: "x"}), "invalid bullet 'x', use '-', '*' or '+'")
+	assert.EqualError(t, SetStyle(Style{Emphasis: "~"}), "invalid emphasis '~', use '_' or '*'")
+	assert.EqualError(t, SetStyle(Style{BlankLines: "none"}), "invalid blank lines policy 'none', use 'compact' or 'spaced'")
+	assert.EqualError(t, SetStyle(Style{LinkStyle: "auto"}), "invalid link style 'auto', use 'reference' or 'inline'")
+	assert.EqualError(t, SetStyle(Style{DateFormat: "Jan 2"}), "invalid date format 'Jan 2', use a layout like '2006-01-02'")
+	assert.Equal(t, "*", CurrentStyle().Bullet)
+}
+
+func TestStyleRender(t *testing.T) {
+	defer SetStyle(DefaultStyle)
+
+	c := &Changelog{
+		Versions: []*Version{
+			{
+				Name: "1.0.0",
+				Date: "2020-01-02",
+				Link: "https://example.com/compare/v0.1.0...v1.0.0",
+				Changes: []*ChangeList{
+					{
+						Type: Added,
+						Items: []*Item{
+							{
+								Description: "A _long_ description of `snake_case` names that\nneeds to be wrapped - twice",
+								Children:    []*Item{{Description: "Child with _emphasis_"}},
+							},
+						},
+					},
+					{Type: Fixed, Items: []*Item{{Description: "Fix in [docs](https://example.com/a_b_c)"}}},
+				},
+			},
+		},
+	}
+
+	err := SetStyle(Style{
+		Wrap:       30,
+		Bullet:     "*",
+		Emphasis:   "*",
+		BlankLines: BlankLinesSpaced,
+		LinkStyle:  LinkStyleInline,
+	})
+	assert.NoError(t, err)
+
+	expected := `# Changelog
+
+## [1.0.0](https://example.com/compare/v0.1.0...v1.0.0) - 2020-01-02
+
+### Added
+
+* A *long* description of
+  ` + "`snake_case`" + ` names that
+  needs to be wrapped - twice
+    * Child with *emphasis*
+
+### Fixed
+
+* Fix in
+  [docs](https://example.com/a_b_c)
+`
+
+	var buf bytes.Buffer
+	c.Render(&buf)
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderStyle(t *testing.T) {
+	c := &Changelog{
+		Versions: []*Version{
+			{
+				Name:    "1.0.0",
+				Date:    "2020-01-31",
+				Changes: []*ChangeList{{Type: Added, Items: []*Item{{Description: "Item"}}}},
+			},
+		},
+	}
+
+	s, err := Style{Bullet: "+", DateFormat: "02/01/2006"}.Normalize()
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	c.RenderStyle(&buf, s)
+
+	assert.Equal(t, "# Changelog\n\n## 1.0.0 - 31/01/2020\n### Added\n+ Item\n", buf.String())
+	assert.Equal(t, DefaultStyle, CurrentStyle())
+
+	_, err = Style{Bullet: "x"}.Normalize()
+	assert.EqualError(t, err, "invalid bullet 'x', use '-', '*' or '+'")
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, "one two\nthree", wrap("one two three", 8))
+	assert.Equal(t, "one\nverylongword\ntwo", wrap("one verylongword two", 5))
+	assert.Equal(t, "a b -\nc", wrap("a b - c", 4))
+}
+
+func TestReplaceEmphasis(t *testing.T) {
+	assert.Equal(t, "*a* and **b**", replaceEmphasis("_a_ and **b**", "*"))
+	assert.Equal(t, "snake_case and `_code_`", replaceEmphasis("snake_case and `_code_`", "*"))
+	assert.Equal(t, "(*note*) a _ b", replaceEmphasis("(_note_) a _ b", "*"))
+}
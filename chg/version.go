@@ -1,18 +1,22 @@
 package chg
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"io"
 	"sort"
+	"strings"
 )
 
 // Version stores information about the version being defined and
 // its sections
 type Version struct {
-	Name    string
-	Date    string // Date in the format YYYY-MM-DD
-	Link    string
-	Yanked  bool // True if the release was yanked/removed
-	Changes []*ChangeList
+	Name       string
+	Date       string // Release date as written, in a format accepted by ParseDate
+	Link       string
+	Yanked     bool // True if the release was yanked/removed
+	Superseded bool // True if the pre-release was replaced by its final release
+	Changes    []*ChangeList
 }
 
 // Change returns the Change with name
@@ -26,17 +30,69 @@ func (v *Version) Change(ct ChangeType) *ChangeList {
 	return nil
 }
 
+// AddItem includes the message under the section, creating it if needed
+func (v *Version) AddItem(section ChangeType, message string) *Item {
+	s := v.Change(section)
+	if s == nil {
+		s = &ChangeList{Type: section}
+		v.Changes = append(v.Changes, s)
+	}
+	item := &Item{
+		Description: message,
+	}
+	s.Items = append(s.Items, item)
+	return item
+}
+
 // SortChanges sort the changes ascending
 func (v *Version) SortChanges() {
 	sort.Slice(v.Changes, func(i, j int) bool {
-		return v.Changes[i].Type < v.Changes[j].Type
+		return v.Changes[i].Type.order() < v.Changes[j].Type.order()
 	})
 }
 
+// IsUnreleased checks if it's the Unreleased version
+func (v *Version) IsUnreleased() bool {
+	return strings.ToLower(v.Name) == "unreleased"
+}
+
+// Hash returns a digest of the version name, metadata and items.
+// Cosmetic differences, like the order of the sections, line wrapping
+// or the date format, don't change it.
+func (v *Version) Hash() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "name:%s\ndate:%s\nlink:%s\nyanked:%t\n", v.Name, hashDate(v.Date), v.Link, v.Yanked)
+	if v.Superseded {
+		// Only when set, keeping the hashes of the lockfiles created before
+		io.WriteString(h, "superseded:true\n")
+	}
+
+	changes := make([]*ChangeList, len(v.Changes))
+	copy(changes, v.Changes)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Type.order() < changes[j].Type.order()
+	})
+
+	for _, c := range changes {
+		fmt.Fprintf(h, "type:%s\n", c.Type.Name())
+		for _, i := range c.Items {
+			fmt.Fprintf(h, "item:%s\n", normalizeText(i.Text()))
+		}
+	}
+
+	return fmt.Sprintf("sha256:%x", h.Sum(nil))
+}
+
 // RenderTitle writes the title in correct format
 func (v *Version) RenderTitle(w io.Writer) {
+	v.renderTitle(w, style)
+}
+
+func (v *Version) renderTitle(w io.Writer, s Style) {
 	io.WriteString(w, "## ")
-	if v.Link != "" {
+	if v.Link != "" && s.LinkStyle == LinkStyleInline {
+		io.WriteString(w, fmt.Sprintf("[%s](%s)", v.Name, v.Link))
+	} else if v.Link != "" {
 		io.WriteString(w, "[")
 		io.WriteString(w, v.Name)
 		io.WriteString(w, "]")
@@ -45,26 +101,42 @@ func (v *Version) RenderTitle(w io.Writer) {
 	}
 	if v.Date != "" {
 		io.WriteString(w, " - ")
-		io.WriteString(w, v.Date)
+		io.WriteString(w, formatDate(v.Date, s, s.DateFormat))
 	}
 	if v.Yanked {
 		io.WriteString(w, " [YANKED]")
 	}
+	if v.Superseded {
+		io.WriteString(w, " [SUPERSEDED]")
+	}
 }
 
 // RenderChanges writes all the changes
 func (v *Version) RenderChanges(w io.Writer) {
+	v.renderChanges(w, style)
+}
+
+func (v *Version) renderChanges(w io.Writer, s Style) {
 	for i, c := range v.Changes {
 		if i > 0 {
 			io.WriteString(w, "\n")
 		}
-		c.Render(w)
+		c.render(w, s)
 	}
 }
 
 // Render writes the title and changes
 func (v *Version) Render(w io.Writer) {
-	v.RenderTitle(w)
+	v.RenderStyle(w, style)
+}
+
+// RenderStyle writes the title and changes in the style instead of the
+// configured one. The style must be normalized (see Style.Normalize).
+func (v *Version) RenderStyle(w io.Writer, s Style) {
+	v.renderTitle(w, s)
 	io.WriteString(w, "\n")
-	v.RenderChanges(w)
+	if s.BlankLines == BlankLinesSpaced && len(v.Changes) > 0 {
+		io.WriteString(w, "\n")
+	}
+	v.renderChanges(w, s)
 }
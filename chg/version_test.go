@@ -111,15 +111,15 @@ func TestRenderChanges(t *testing.T) {
 		{
 			Type: Added,
 			Items: []*Item{
-				{"Item 1"},
-				{"Item 2"},
+				{Description: "Item 1"},
+				{Description: "Item 2"},
 			},
 		},
 		{
 			Type: Changed,
 			Items: []*Item{
-				{"Item A"},
-				{"Item B"},
+				{Description: "Item A"},
+				{Description: "Item B"},
 			},
 		},
 	}
@@ -147,15 +147,15 @@ func TestVersionRender(t *testing.T) {
 		{
 			Type: Added,
 			Items: []*Item{
-				{"Item 1"},
-				{"Item 2"},
+				{Description: "Item 1"},
+				{Description: "Item 2"},
 			},
 		},
 		{
 			Type: Changed,
 			Items: []*Item{
-				{"Item A"},
-				{"Item B"},
+				{Description: "Item A"},
+				{Description: "Item B"},
 			},
 		},
 	}
@@ -178,3 +178,57 @@ func TestVersionRender(t *testing.T) {
 
 	assert.Equal(t, expected, result)
 }
+
+func TestVersionIsUnreleased(t *testing.T) {
+	assert.True(t, (&Version{Name: "unreleased"}).IsUnreleased())
+	assert.False(t, (&Version{Name: "1.0.0"}).IsUnreleased())
+}
+
+func TestVersionHash(t *testing.T) {
+	v := &Version{
+		Name: "1.0.0",
+		Date: "2020-01-01",
+		Changes: []*ChangeList{
+			{Type: Fixed, Items: []*Item{{Description: "A long\nitem"}}},
+			{Type: Added, Items: []*Item{{Description: "Item"}}},
+		},
+	}
+
+	reformatted := &Version{
+		Name: "1.0.0",
+		Date: "2020-01-01",
+		Changes: []*ChangeList{
+			{Type: Added, Items: []*Item{{Description: "Item"}}},
+			{Type: Fixed, Items: []*Item{{Description: "A long item"}}},
+		},
+	}
+	assert.Equal(t, v.Hash(), reformatted.Hash())
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", v.Hash())
+
+	modified := *reformatted
+	modified.Date = "2020-01-02"
+	assert.NotEqual(t, v.Hash(), modified.Hash())
+}
+
+func TestVersionHashDateFormat(t *testing.T) {
+	defer SetStyle(DefaultStyle)
+
+	v := &Version{Name: "1.0.0", Date: "03/04/2024"}
+	hash := v.Hash()
+
+	assert.NoError(t, SetStyle(Style{DateFormat: "01/02/2006"}))
+	assert.Equal(t, hash, v.Hash())
+	assert.Equal(t, hash, (&Version{Name: "1.0.0", Date: "2024-04-03"}).Hash())
+}
+
+func TestVersionAddItem(t *testing.T) {
+	v := &Version{Name: "1.0.0"}
+
+	item := v.AddItem(Fixed, "Item 1")
+	v.AddItem(Fixed, "Item 2")
+
+	assert.Equal(t, "Item 1", item.Description)
+	assert.Len(t, v.Changes, 1)
+	assert.Equal(t, Fixed, v.Changes[0].Type)
+	assert.Len(t, v.Changes[0].Items, 2)
+}
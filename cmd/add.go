@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/rcmachado/changelog/changelog"
+	"github.com/rcmachado/changelog/chg"
+	"github.com/spf13/cobra"
+)
+
+// editFunc lets the user edit text, returning the result
+type editFunc func(initial string) (string, error)
+
+const editorTemplate = `
+# Describe the change. Lines starting with '#' are ignored and an empty
+# description aborts the command.
+`
+
+func newAddCmd(iostreams *IOStreams, edit editFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add an item, prompting for its details",
+		Long: `Add an item to the changelog, prompting for the change type, description,
+issue references and version. Values informed with flags aren't asked and
+--yes accepts the defaults of the optional ones.
+
+Leave the description empty to write it in $VISUAL or $EDITOR, which is
+useful for multi-line text. A preview of the section is shown before
+saving.`,
+		Example: `  changelog add -w
+  changelog add -w --type fixed --message "Crash on empty files" --issue 42 --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			fs := cmd.Flags()
+			typeName, _ := fs.GetString("type")
+			message, _ := fs.GetString("message")
+			issues, _ := fs.GetStringSlice("issue")
+			versionName, _ := fs.GetString("version")
+			yes, _ := fs.GetBool("yes")
+			write, _ := fs.GetBool("write")
+			filename, _ := fs.GetString("filename")
+
+			if write && (filename == "" || filename == "-") {
+				return fmt.Errorf("--write requires a changelog file")
+			}
+
+			doc, err := readInputToRewrite(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+			p := &prompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr()}
+
+			ct, err := askChangeType(p, fs.Changed("type"), typeName)
+			if err != nil {
+				return err
+			}
+
+			if !fs.Changed("message") {
+				if message, err = p.ask("Description (empty to open the editor)", ""); err != nil {
+					return err
+				}
+				if message == "" {
+					if message, err = editDescription(edit); err != nil {
+						return err
+					}
+				}
+			}
+			message = strings.TrimSpace(message)
+			if message == "" {
+				return fmt.Errorf("Aborted: empty description")
+			}
+
+			if !fs.Changed("issue") && !yes {
+				answer, err := p.ask("Issue references, comma separated (optional)", "")
+				if err != nil {
+					return err
+				}
+				issues = strings.Split(answer, ",")
+			}
+
+			if !fs.Changed("version") && !yes {
+				if versionName, err = p.ask("Version", versionName); err != nil {
+					return err
+				}
+			}
+
+			_, err = doc.Add(ct.Name(), message, changelog.WithVersion(versionName), changelog.WithIssues(issues...))
+			if errors.Is(err, changelog.ErrUnknownVersion) {
+				return fmt.Errorf("Unknown version: '%s'", versionName)
+			}
+			if err != nil {
+				return fmt.Errorf("Failed to add item: %s", err)
+			}
+			version := doc.Version(versionName)
+
+			fmt.Fprintf(p.out, "\n")
+			version.RenderTitle(p.out)
+			fmt.Fprintf(p.out, "\n")
+			version.Change(ct).Render(p.out)
+			fmt.Fprintf(p.out, "\n")
+
+			if !yes {
+				ok, err := p.confirm("Save?")
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("Aborted")
+				}
+			}
+
+			if !write {
+				return doc.Render(cmd.Context(), iostreams.Out)
+			}
+			return doc.SaveAs(cmd.Context(), filename)
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.StringP("type", "t", "", "Change type (eg. added, fixed)")
+	fs.StringP("message", "m", "", "Description of the change")
+	fs.StringSlice("issue", nil, "Issue reference, eg. 123 or org/repo#123 (can be repeated)")
+	fs.String("version", "Unreleased", "Version to add the item to")
+	fs.BoolP("yes", "y", false, "Use the defaults and save without asking for confirmation")
+	fs.BoolP("write", "w", false, "Write the result to the changelog file instead of the output")
+
+	return cmd
+}
+
+func askChangeType(p *prompter, informed bool, name string) (chg.ChangeType, error) {
+	if informed {
+		ct := chg.ChangeTypeFromString(name)
+		if ct == chg.Unknown {
+			return ct, fmt.Errorf("Unknown change type: '%s'", name)
+		}
+		return ct, nil
+	}
+
+	types := chg.ChangeTypes()
+	for idx, ct := range types {
+		fmt.Fprintf(p.out, "%d) %s\n", idx+1, ct.String())
+	}
+
+	for {
+		answer, err := p.ask("Change type", "")
+		if err != nil {
+			return chg.Unknown, err
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(types) {
+			return types[n-1], nil
+		}
+		if ct := chg.ChangeTypeFromString(answer); ct != chg.Unknown {
+			return ct, nil
+		}
+		fmt.Fprintf(p.out, "Unknown change type: '%s'\n", answer)
+	}
+}
+
+// editDescription opens the editor with the template and returns the
+// text without the comments
+func editDescription(edit editFunc) (string, error) {
+	text, err := edit(editorTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, strings.TrimRight(line, " \t\r"))
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
+
+// runEditor edits the text in $VISUAL or $EDITOR, like git does
+func runEditor(initial string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	f, err := ioutil.TempFile("", "CHANGELOG_ITEM_*.md")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", err
+	}
+	f.Close()
+
+	// The editor may have arguments, so it's run by the shell
+	c := exec.Command("sh", "-c", editor+` "$@"`, editor, f.Name())
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("Failed to run editor '%s': %s", editor, err)
+	}
+
+	content, err := ioutil.ReadFile(f.Name())
+	return string(content), err
+}
+
+// prompter asks questions in the terminal
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask returns the answer, or def when it's empty
+func (p *prompter) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+
+	answer, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		return "", fmt.Errorf("Failed to read answer: %s", err)
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+func (p *prompter) confirm(question string) (bool, error) {
+	answer, err := p.ask(question+" [Y/n]", "")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "" || answer == "y" || answer == "yes", nil
+}
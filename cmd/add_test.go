@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAddCmd(t *testing.T, answers string, edit editFunc, args ...string) (*bytes.Buffer, *bytes.Buffer, error) {
+	changelog, err := ioutil.ReadFile("testdata/show-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	if edit == nil {
+		edit = func(string) (string, error) {
+			t.Fatal("editor should not be opened")
+			return "", nil
+		}
+	}
+
+	prompts := new(bytes.Buffer)
+	cmd := newAddCmd(iostreams, edit)
+	cmd.Flags().String("filename", "CHANGELOG.md", "")
+	cmd.SetArgs(args)
+	cmd.SetIn(strings.NewReader(answers))
+	cmd.SetErr(prompts)
+	_, err = cmd.ExecuteC()
+
+	return out, prompts, err
+}
+
+func TestAddCmdPrompts(t *testing.T) {
+	answers := strings.Join([]string{
+		"bugfix", // unknown change type, asked again
+		"4",      // Fixed
+		"Crash on start",
+		"12, org/repo#3",
+		"", // Unreleased
+		"y",
+	}, "\n") + "\n"
+
+	out, prompts, err := newTestAddCmd(t, answers, nil)
+
+	assert.Nil(t, err)
+	assert.Contains(t, prompts.String(), "1) Added\n2) Changed\n")
+	assert.Contains(t, prompts.String(), "Unknown change type: 'bugfix'\n")
+	assert.Contains(t, prompts.String(), "Version [Unreleased]: ")
+	assert.Contains(t, prompts.String(), "## [Unreleased]\n### Fixed\n- Crash on start (#12, org/repo#3)\n\nSave? [Y/n]: ")
+	assert.Contains(t, out.String(), "## [Unreleased]\n### Fixed\n- Crash on start (#12, org/repo#3)\n\n### Removed\n- Item 4\n")
+}
+
+func TestAddCmdEditor(t *testing.T) {
+	answers := "added\n\n\n1.0.0\n\n"
+	edit := func(initial string) (string, error) {
+		assert.Contains(t, initial, "# Describe the change.")
+		return "New API\n\nSee the migration guide.\n" + initial, nil
+	}
+
+	out, _, err := newTestAddCmd(t, answers, edit)
+
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "## [1.0.0] - 2020-01-08\n### Added\n- Item 1\n- Item 2\n- New API\n\n    See the migration guide.\n")
+}
+
+func TestAddCmdEmptyDescription(t *testing.T) {
+	edit := func(initial string) (string, error) {
+		return initial, nil
+	}
+
+	out, _, err := newTestAddCmd(t, "added\n\n", edit)
+
+	assert.EqualError(t, err, "Aborted: empty description")
+	assert.Empty(t, out.String())
+}
+
+func TestAddCmdAbort(t *testing.T) {
+	out, _, err := newTestAddCmd(t, "\nn\n", nil, "--type", "fixed", "--message", "Crash", "--issue", "1")
+
+	assert.EqualError(t, err, "Aborted")
+	assert.Empty(t, out.String())
+}
+
+func TestAddCmdFlags(t *testing.T) {
+	out, prompts, err := newTestAddCmd(t, "", nil,
+		"--type", "security", "--message", "Escape HTML", "--issue", "7", "--version", "1.0.0", "--yes")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "\n## [1.0.0] - 2020-01-08\n### Security\n- Escape HTML (#7)\n\n", prompts.String())
+	assert.Contains(t, out.String(), "### Security\n- Escape HTML (#7)\n\n[Unreleased]")
+}
+
+func TestAddCmdErrors(t *testing.T) {
+	_, _, err := newTestAddCmd(t, "", nil, "--type", "typo", "--message", "x", "--yes")
+	assert.EqualError(t, err, "Unknown change type: 'typo'")
+
+	_, _, err = newTestAddCmd(t, "", nil, "--type", "fixed", "--message", "x", "--version", "9.9.9", "--yes")
+	assert.EqualError(t, err, "Unknown version: '9.9.9'")
+}
+
+func TestAddCmdWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "changelog-add")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "CHANGELOG.md")
+	ioutil.WriteFile(filename, []byte("# Changelog\n"), 0644)
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{In: strings.NewReader("# Changelog\n"), Out: out}
+
+	cmd := newAddCmd(iostreams, nil)
+	cmd.Flags().String("filename", filename, "")
+	cmd.SetArgs([]string{"-w", "-t", "added", "-m", "Item", "-y"})
+	cmd.SetErr(new(bytes.Buffer))
+	_, err = cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Empty(t, out.String())
+	content, _ := ioutil.ReadFile(filename)
+	assert.Equal(t, "# Changelog\n\n## Unreleased\n### Added\n- Item\n", string(content))
+}
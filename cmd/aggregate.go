@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/config"
+	"github.com/spf13/cobra"
+)
+
+func newAggregateCmd(iostreams *IOStreams, cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "aggregate [component=path]...",
+		Short: "Combine component changelogs into a product changelog",
+		Long: `Combine the changelogs of several components into a single product changelog.
+
+Components and product versions come from the 'aggregate' section of
+the configuration file or from --manifest. Components informed as
+arguments replace the configured ones.
+
+Each product version includes the component versions listed in its
+'components' mapping or, when there is none, the component versions
+released after the previous product version up to its date. Items are
+prefixed with the component name and identical items are merged.`,
+		Annotations: map[string]string{annotationNoInput: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			manifest := &cfg.Aggregate
+			if filename, _ := cmd.Flags().GetString("manifest"); filename != "" {
+				var err error
+				if manifest, err = config.LoadAggregate(filename); err != nil {
+					return fmt.Errorf("Failed to load manifest '%s': %s", filename, err)
+				}
+			}
+
+			sources := manifest.Components
+			if len(args) > 0 {
+				sources = nil
+				for _, arg := range args {
+					parts := strings.SplitN(arg, "=", 2)
+					if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+						return fmt.Errorf("Invalid argument '%s', expected component=path", arg)
+					}
+					sources = append(sources, config.Component{Name: parts[0], Path: parts[1]})
+				}
+			}
+			if len(sources) == 0 {
+				return fmt.Errorf("No components informed")
+			}
+
+			components := make([]chg.Component, len(sources))
+			for idx, src := range sources {
+				doc, _, err := readChangelog(cmd.Context(), src.Path)
+				if err != nil {
+					return fmt.Errorf("Failed to read component '%s': %s", src.Name, err)
+				}
+				components[idx] = chg.Component{Name: src.Name, Changelog: doc.Changelog}
+			}
+
+			changelog := chg.Aggregate(components, manifest.ProductVersions())
+			changelog.Render(iostreams.Out)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("manifest", "m", "", "Aggregate manifest (defaults to the configuration file)")
+	cmd.MarkFlagFilename("manifest")
+
+	return cmd
+}
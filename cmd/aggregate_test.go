@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateCmd(t *testing.T) {
+	expected := `# Changelog
+
+## Unreleased
+### Added
+- api: Pagination
+
+## 2.0.0 - 2020-08-01
+### Fixed
+- api: Timeout
+- api, web: Update dependencies
+
+## 1.0.0 - 2020-06-02
+### Added
+- api: Users endpoint
+- web: Login page
+`
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{Out: out}
+
+	cmd := newAggregateCmd(iostreams, config.Default())
+	cmd.SetArgs([]string{"--manifest", "testdata/aggregate/manifest.yml"})
+	_, err := cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out.String())
+}
+
+func TestAggregateCmdComponentArgs(t *testing.T) {
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{Out: out}
+
+	cmd := newAggregateCmd(iostreams, config.Default())
+	cmd.SetArgs([]string{"web=testdata/aggregate/web.md"})
+	_, err := cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "- web: Login page\n")
+}
+
+func TestAggregateCmdErrors(t *testing.T) {
+	iostreams := &IOStreams{Out: new(bytes.Buffer)}
+
+	t.Run("no-components", func(t *testing.T) {
+		cmd := newAggregateCmd(iostreams, config.Default())
+		_, err := cmd.ExecuteC()
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid-argument", func(t *testing.T) {
+		cmd := newAggregateCmd(iostreams, config.Default())
+		cmd.SetArgs([]string{"web"})
+		_, err := cmd.ExecuteC()
+		assert.Error(t, err)
+	})
+
+	t.Run("missing-file", func(t *testing.T) {
+		cmd := newAggregateCmd(iostreams, config.Default())
+		cmd.SetArgs([]string{"web=testdata/aggregate/missing.md"})
+		_, err := cmd.ExecuteC()
+		assert.Error(t, err)
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/rcmachado/changelog/chg"
-	"github.com/rcmachado/changelog/parser"
 	"github.com/spf13/cobra"
 )
 
@@ -13,22 +12,27 @@ func newChangeTypeCmd(iostreams *IOStreams, ct chg.ChangeType) *cobra.Command {
 	sectionName := ct.String()
 
 	return &cobra.Command{
-		Use:   strings.ToLower(sectionName),
+		Use:   changeTypeCmdName(ct),
 		Short: fmt.Sprintf("Add item under '%s' section", sectionName),
 		Args:  cobra.MinimumNArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			changelog := parser.Parse(iostreams.In)
-			changelog.AddItem(ct, strings.Join(args, " "))
-			changelog.Render(iostreams.Out)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			doc, err := readInputToRewrite(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+
+			if _, err := doc.Add(ct.Name(), strings.Join(args, " ")); err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("Failed to add item: %s", err)
+			}
+			return doc.Render(cmd.Context(), iostreams.Out)
 		},
 	}
 
 }
 
 func newChangeTypeCmds(iostreams *IOStreams) []*cobra.Command {
-	cmdTypes := []chg.ChangeType{
-		chg.Added, chg.Changed, chg.Deprecated, chg.Fixed, chg.Removed, chg.Security,
-	}
+	cmdTypes := chg.ChangeTypes()
 
 	allCmds := make([]*cobra.Command, len(cmdTypes))
 
@@ -40,3 +44,29 @@ func newChangeTypeCmds(iostreams *IOStreams) []*cobra.Command {
 
 	return allCmds
 }
+
+// changeTypeCmdName returns the name of the command adding items of ct,
+// eg. "breaking-changes"
+func changeTypeCmdName(ct chg.ChangeType) string {
+	return strings.ToLower(strings.Join(strings.Fields(ct.Name()), "-"))
+}
+
+// checkChangeTypeNames fails when the command of a change type would
+// have the name of one of the commands of root, which makes it
+// unreachable
+func checkChangeTypeNames(root *cobra.Command) error {
+	names := map[string]string{"help": "help"}
+	for _, cmd := range root.Commands() {
+		names[cmd.Name()] = cmd.Name()
+		for _, alias := range cmd.Aliases {
+			names[alias] = cmd.Name()
+		}
+	}
+
+	for _, ct := range chg.ChangeTypes() {
+		if cmdName, ok := names[changeTypeCmdName(ct)]; ok {
+			return fmt.Errorf("'%s' conflicts with the command '%s'", ct.Name(), cmdName)
+		}
+	}
+	return nil
+}
@@ -46,3 +46,46 @@ func TestNewChangeTypeCmds(t *testing.T) {
 	allCmds := newChangeTypeCmds(iostreams)
 	assert.Len(t, allCmds, 6)
 }
+
+func TestNewChangeTypeCmdsCustomTypes(t *testing.T) {
+	defer chg.SetChangeTypes(chg.DefaultChangeTypes)
+
+	err := chg.SetChangeTypes([]chg.ChangeTypeDef{
+		{Name: "Fixed", Heading: "Corrigido"},
+		{Name: "Breaking Changes"},
+	})
+	assert.NoError(t, err)
+
+	iostreams := &IOStreams{
+		In:  new(bytes.Buffer),
+		Out: new(bytes.Buffer),
+	}
+
+	allCmds := newChangeTypeCmds(iostreams)
+	assert.Len(t, allCmds, 2)
+	assert.Equal(t, "fixed", allCmds[0].Name())
+	assert.Equal(t, "Add item under 'Corrigido' section", allCmds[0].Short)
+	assert.Equal(t, "breaking-changes", allCmds[1].Name())
+}
+
+func TestCheckChangeTypeNames(t *testing.T) {
+	defer chg.SetChangeTypes(chg.DefaultChangeTypes)
+
+	assert.NoError(t, checkChangeTypeNames(rootCmd))
+
+	assert.NoError(t, chg.SetChangeTypes([]chg.ChangeTypeDef{{Name: "Fixed"}, {Name: "Release"}}))
+	assert.EqualError(t, checkChangeTypeNames(rootCmd), "'Release' conflicts with the command 'release'")
+}
+
+func TestConfigFromArgs(t *testing.T) {
+	t.Run("default-missing", func(t *testing.T) {
+		cfg, err := configFromArgs([]string{"fmt"})
+		assert.NoError(t, err)
+		assert.Equal(t, chg.DefaultChangeTypes, cfg.ChangeTypeDefs())
+	})
+
+	t.Run("explicit-missing", func(t *testing.T) {
+		_, err := configFromArgs([]string{"fmt", "--config", "testdata/missing.yml"})
+		assert.Error(t, err)
+	})
+}
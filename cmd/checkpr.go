@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/git"
+	"github.com/spf13/cobra"
+)
+
+func newCheckPRCmd(iostreams *IOStreams, cfg *config.Config, repo *git.Repo) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check-pr",
+		Short: "Require an Unreleased entry when code changes",
+		Long: `Fail when the changes since --base touch code without adding an item
+under Unreleased, or when items were added to released versions.
+
+What counts as code is configured by --include and --exclude globs
+('**' matches any number of directories). The check is skipped when
+the pull request has the skip label (see --label) or when a commit
+since --base has the skip trailer, eg. 'Changelog: skip'.`,
+		Example: `  changelog check-pr --base origin/main --label "$PR_LABELS"`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			fs := cmd.Flags()
+			base, _ := fs.GetString("base")
+			labels, _ := fs.GetStringSlice("label")
+			filename, _ := fs.GetString("filename")
+
+			opts := cfg.CheckPR
+			if fs.Changed("include") {
+				opts.Include, _ = fs.GetStringSlice("include")
+			}
+			if fs.Changed("exclude") {
+				opts.Exclude, _ = fs.GetStringSlice("exclude")
+			}
+
+			out := iostreams.Out
+
+			for _, label := range labels {
+				if opts.SkipLabel != "" && strings.EqualFold(label, opts.SkipLabel) {
+					fmt.Fprintf(out, "Skipped: pull request has label '%s'\n", label)
+					return nil
+				}
+			}
+
+			rev := base
+			if mergeBase, err := repo.MergeBase(base, "HEAD"); err == nil {
+				rev = mergeBase
+			}
+
+			messages, err := repo.CommitMessages(rev)
+			if err != nil {
+				return err
+			}
+			for _, msg := range messages {
+				if hasTrailer(msg, opts.SkipTrailer) {
+					fmt.Fprintf(out, "Skipped: commit has trailer '%s'\n", opts.SkipTrailer)
+					return nil
+				}
+			}
+
+			files, err := repo.ChangedFiles(rev)
+			if err != nil {
+				return err
+			}
+			exclude := append([]string{filepath.ToSlash(filename)}, opts.Exclude...)
+			if codeFiles := filterPaths(files, opts.Include, exclude); len(codeFiles) == 0 {
+				fmt.Fprintln(out, "No code changes, changelog entry not required")
+				return nil
+			}
+
+			doc, err := readInput(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+			changelog := doc.Changelog
+
+			added := 0
+			var problems []string
+			if content, err := repo.Show(rev, filename); err == nil {
+				previous, err := parseChangelog(cmd.Context(), content)
+				if err != nil {
+					return fmt.Errorf("Failed to read changelog at %s: %s", base, err)
+				}
+				for _, d := range chg.Diff(previous, changelog) {
+					if d.Field != "item" || d.Kind != chg.DiffAdded {
+						continue
+					}
+					if strings.EqualFold(d.Version, "Unreleased") {
+						added++
+					} else {
+						problems = append(problems, fmt.Sprintf("item added to released version %s: %s", d.Version, d.Item))
+					}
+				}
+			} else {
+				// The changelog is new, only its Unreleased items count
+				fmt.Fprintf(out, "No changelog in %s, %s is new\n", base, filename)
+				if unreleased := changelog.Version("Unreleased"); unreleased != nil {
+					for _, change := range unreleased.Changes {
+						added += len(change.Items)
+					}
+				}
+			}
+			if added == 0 {
+				problems = append([]string{"code changed but no item was added under Unreleased"}, problems...)
+			}
+
+			for _, p := range problems {
+				fmt.Fprintln(out, p)
+			}
+			if len(problems) > 0 {
+				return fmt.Errorf("Changelog check failed")
+			}
+
+			fmt.Fprintf(out, "Found %d new item(s) under Unreleased\n", added)
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.String("base", "", "Base revision of the pull request, eg. origin/main")
+	cmd.MarkFlagRequired("base")
+	fs.StringSlice("label", nil, "Labels of the pull request")
+	fs.StringSlice("include", nil, "Globs of paths that count as code changes (default all)")
+	fs.StringSlice("exclude", nil, "Globs of paths that don't count as code changes")
+
+	return cmd
+}
+
+// hasTrailer checks if the commit message has the "Key: value" trailer
+func hasTrailer(message, trailer string) bool {
+	key, value := splitTrailer(trailer)
+	if key == "" {
+		return false
+	}
+
+	for _, line := range strings.Split(message, "\n") {
+		k, v := splitTrailer(line)
+		if strings.EqualFold(k, key) && strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitTrailer(s string) (string, string) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
+
+// filterPaths returns the paths matching any of the include globs
+// (or all, if there is none) and none of the exclude globs
+func filterPaths(paths, include, exclude []string) []string {
+	var result []string
+	for _, p := range paths {
+		if len(include) > 0 && !matchAnyPattern(include, p) {
+			continue
+		}
+		if matchAnyPattern(exclude, p) {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+func matchAnyPattern(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern matches path against a glob where '**' matches any
+// number of directories. Patterns without '/' match the file name in
+// any directory and patterns ending in '/' match everything below it.
+func matchPattern(pattern, path string) bool {
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !strings.Contains(pattern, "/") {
+		expr.WriteString("(.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				expr.WriteString("(.*/)?")
+				i += 2
+			} else if strings.HasPrefix(pattern[i:], "**") {
+				expr.WriteString(".*")
+				i++
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return false
+	}
+	return re.MatchString(path)
+}
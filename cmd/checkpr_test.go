@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/git"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestGitRepo creates a repository with a commit containing
+// CHANGELOG.md (from testdata/show-changelog.md) and main.go
+func newTestGitRepo(t *testing.T) (*git.Repo, func()) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir, err := ioutil.TempDir("", "changelog-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changelog, err := ioutil.ReadFile("testdata/show-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.WriteFile(filepath.Join(dir, "CHANGELOG.md"), changelog, 0644)
+	ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644)
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"add", "."},
+		{"commit", "-q", "-m", "Initial commit"},
+	} {
+		runGit(t, dir, args...)
+	}
+
+	return &git.Repo{Dir: dir}, func() { os.RemoveAll(dir) }
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %s", strings.Join(args, " "), out)
+	}
+}
+
+func executeCheckPRCmd(repo *git.Repo, changelog string, args ...string) (string, error) {
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  strings.NewReader(changelog),
+		Out: out,
+	}
+
+	cmd := newCheckPRCmd(iostreams, config.Default(), repo)
+	cmd.Flags().String("filename", "CHANGELOG.md", "")
+	cmd.SetArgs(append([]string{"--base", "HEAD"}, args...))
+	_, err := cmd.ExecuteC()
+
+	return out.String(), err
+}
+
+func readChangelogString(t *testing.T, repo *git.Repo) string {
+	content, err := ioutil.ReadFile(filepath.Join(repo.Dir, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestCheckPRCmd(t *testing.T) {
+	repo, cleanup := newTestGitRepo(t)
+	defer cleanup()
+
+	original := readChangelogString(t, repo)
+
+	t.Run("no-code-changes", func(t *testing.T) {
+		out, err := executeCheckPRCmd(repo, original)
+		assert.NoError(t, err)
+		assert.Equal(t, "No code changes, changelog entry not required\n", out)
+	})
+
+	ioutil.WriteFile(filepath.Join(repo.Dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644)
+
+	t.Run("missing-entry", func(t *testing.T) {
+		out, err := executeCheckPRCmd(repo, original)
+		assert.Error(t, err)
+		assert.Equal(t, "code changed but no item was added under Unreleased\n", out)
+	})
+
+	t.Run("new-entry", func(t *testing.T) {
+		changelog := strings.Replace(original, "- Item 4\n", "- Item 4\n- Item 5\n", 1)
+		out, err := executeCheckPRCmd(repo, changelog)
+		assert.NoError(t, err)
+		assert.Equal(t, "Found 1 new item(s) under Unreleased\n", out)
+	})
+
+	t.Run("released-version-changed", func(t *testing.T) {
+		changelog := strings.Replace(original, "- Item 3\n", "- Item 3\n- Item 5\n", 1)
+		changelog = strings.Replace(changelog, "- Item 4\n", "- Item 4\n- Item 6\n", 1)
+		out, err := executeCheckPRCmd(repo, changelog)
+		assert.Error(t, err)
+		assert.Equal(t, "item added to released version 1.0.0: Item 5\n", out)
+	})
+
+	t.Run("excluded", func(t *testing.T) {
+		out, err := executeCheckPRCmd(repo, original, "--exclude", "*.go")
+		assert.NoError(t, err)
+		assert.Equal(t, "No code changes, changelog entry not required\n", out)
+	})
+
+	t.Run("skip-label", func(t *testing.T) {
+		out, err := executeCheckPRCmd(repo, original, "--label", "bug,skip-changelog")
+		assert.NoError(t, err)
+		assert.Equal(t, "Skipped: pull request has label 'skip-changelog'\n", out)
+	})
+}
+
+func TestCheckPRCmdNewChangelog(t *testing.T) {
+	repo, cleanup := newTestGitRepo(t)
+	defer cleanup()
+
+	changelog := readChangelogString(t, repo)
+	runGit(t, repo.Dir, "rm", "-q", "CHANGELOG.md")
+	runGit(t, repo.Dir, "commit", "-q", "-m", "Remove changelog")
+	ioutil.WriteFile(filepath.Join(repo.Dir, "util.go"), []byte("package main\n"), 0644)
+
+	out, err := executeCheckPRCmd(repo, changelog)
+	assert.NoError(t, err)
+	assert.Equal(t, "No changelog in HEAD, CHANGELOG.md is new\nFound 1 new item(s) under Unreleased\n", out)
+
+	t.Run("without-unreleased-items", func(t *testing.T) {
+		changelog := strings.Replace(changelog, "- Item 4\n", "", 1)
+		out, err := executeCheckPRCmd(repo, changelog)
+		assert.Error(t, err)
+		assert.Equal(t, "No changelog in HEAD, CHANGELOG.md is new\ncode changed but no item was added under Unreleased\n", out)
+	})
+}
+
+func TestCheckPRCmdSkipTrailer(t *testing.T) {
+	repo, cleanup := newTestGitRepo(t)
+	defer cleanup()
+
+	ioutil.WriteFile(filepath.Join(repo.Dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644)
+	runGit(t, repo.Dir, "commit", "-q", "-a", "-m", "Refactor\n\nChangelog: skip")
+
+	out, err := executeCheckPRCmd(repo, readChangelogString(t, repo), "--base", "HEAD~1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Skipped: commit has trailer 'Changelog: skip'\n", out)
+}
+
+func TestMatchPattern(t *testing.T) {
+	var testData = []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/root.go", true},
+		{"*.go", "README.md", false},
+		{"cmd/*.go", "cmd/root.go", true},
+		{"cmd/*.go", "cmd/sub/root.go", false},
+		{"cmd/**/*.go", "cmd/sub/root.go", true},
+		{"cmd/**/*.go", "cmd/root.go", true},
+		{"docs/", "docs/a/b.md", true},
+		{"docs/", "src/docs.go", false},
+		{"src/**", "src/a/b.c", true},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.pattern+"="+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchPattern(tt.pattern, tt.path))
+		})
+	}
+}
+
+func TestHasTrailer(t *testing.T) {
+	assert.True(t, hasTrailer("Title\n\nchangelog: SKIP", "Changelog: skip"))
+	assert.False(t, hasTrailer("Title\n\nChangelog: required", "Changelog: skip"))
+	assert.False(t, hasTrailer("Title", ""))
+}
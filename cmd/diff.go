@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/git"
+	"github.com/spf13/cobra"
+)
+
+const gitRefPrefix = "git:"
+
+func newDiffCmd(iostreams *IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <a> <b>",
+		Short: "Show the differences between two changelogs",
+		Long: `Compare two changelogs and report added, removed and modified versions,
+items and metadata. Cosmetic differences that 'fmt' normalizes, like
+the order of sections or line wrapping, are ignored.
+
+Each changelog is a file or a git reference in the form 'git:<rev>',
+which reads --filename at revision <rev>, or 'git:<rev>:<path>'.`,
+		Example: `  changelog diff git:HEAD~1 CHANGELOG.md
+  changelog diff git:main git:HEAD --format json`,
+		Args:        cobra.ExactArgs(2),
+		Annotations: map[string]string{annotationNoInput: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			fs := cmd.Flags()
+			format, _ := fs.GetString("format")
+			exitCode, _ := fs.GetBool("exit-code")
+			filename, _ := fs.GetString("filename")
+
+			if format != "text" && format != "json" {
+				return fmt.Errorf("Unknown format '%s'", format)
+			}
+
+			a, err := loadChangelogRef(cmd.Context(), args[0], filename)
+			if err != nil {
+				return err
+			}
+			b, err := loadChangelogRef(cmd.Context(), args[1], filename)
+			if err != nil {
+				return err
+			}
+
+			diffs := chg.Diff(a, b)
+
+			if format == "json" {
+				if diffs == nil {
+					diffs = []chg.Difference{}
+				}
+				enc := json.NewEncoder(iostreams.Out)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(diffs); err != nil {
+					return err
+				}
+			} else {
+				for _, d := range diffs {
+					fmt.Fprintln(iostreams.Out, d)
+				}
+			}
+
+			if exitCode && len(diffs) > 0 {
+				return fmt.Errorf("Found %d difference(s)", len(diffs))
+			}
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.String("format", "text", "Output format: text or json")
+	fs.Bool("exit-code", false, "Exit with an error when there are differences")
+
+	return cmd
+}
+
+// loadChangelogRef parses a changelog from a file or from a git
+// reference ("git:<rev>" or "git:<rev>:<path>")
+func loadChangelogRef(ctx context.Context, ref, filename string) (*chg.Changelog, error) {
+	if !strings.HasPrefix(ref, gitRefPrefix) {
+		doc, _, err := readChangelog(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		return doc.Changelog, nil
+	}
+
+	rev := strings.TrimPrefix(ref, gitRefPrefix)
+	if parts := strings.SplitN(rev, ":", 2); len(parts) == 2 {
+		rev, filename = parts[0], parts[1]
+	}
+
+	repo := &git.Repo{}
+	content, err := repo.Show(rev, filename)
+	if err != nil {
+		return nil, err
+	}
+	return parseChangelog(ctx, content)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func executeDiffCmd(args ...string) (string, error) {
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{Out: out}
+
+	cmd := newDiffCmd(iostreams)
+	cmd.Flags().String("filename", "CHANGELOG.md", "")
+	cmd.SetArgs(args)
+	_, err := cmd.ExecuteC()
+
+	return out.String(), err
+}
+
+func TestDiffCmd(t *testing.T) {
+	expected := `- Unreleased: Removed: Item 4
+~ 1.0.0: date changed from "2020-01-08" to "2020-01-09"
++ 1.0.0: Added: Item 5
+`
+
+	out, err := executeDiffCmd("testdata/show-changelog.md", "testdata/diff-changelog.md")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out)
+}
+
+func TestDiffCmdJSON(t *testing.T) {
+	expected := `{
+    "kind": "added",
+    "version": "1.0.0",
+    "field": "item",
+    "type": "Added",
+    "item": "Item 5"
+  }`
+
+	out, err := executeDiffCmd("testdata/show-changelog.md", "testdata/diff-changelog.md", "--format", "json")
+	assert.Nil(t, err)
+	assert.Contains(t, out, expected)
+
+	out, err = executeDiffCmd("testdata/show-changelog.md", "testdata/show-changelog.md", "--format", "json")
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", out)
+}
+
+func TestDiffCmdExitCode(t *testing.T) {
+	_, err := executeDiffCmd("testdata/show-changelog.md", "testdata/diff-changelog.md", "--exit-code")
+	assert.Error(t, err)
+
+	_, err = executeDiffCmd("testdata/show-changelog.md", "testdata/show-changelog.md", "--exit-code")
+	assert.Nil(t, err)
+}
+
+func TestDiffCmdErrors(t *testing.T) {
+	_, err := executeDiffCmd("testdata/missing.md", "testdata/show-changelog.md")
+	assert.Error(t, err)
+
+	_, err = executeDiffCmd("testdata/show-changelog.md", "testdata/show-changelog.md", "--format", "xml")
+	assert.Error(t, err)
+}
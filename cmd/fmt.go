@@ -1,18 +1,80 @@
 package cmd
 
 import (
-	"github.com/rcmachado/changelog/parser"
+	"errors"
+	"fmt"
+
+	"github.com/rcmachado/changelog/changelog"
+	"github.com/rcmachado/changelog/chg"
 	"github.com/spf13/cobra"
 )
 
 func newFmtCmd(iostreams *IOStreams) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "fmt",
 		Short: "Reformat the change log file",
-		Long:  "Reformats changelog input following keepachangelog.com spec",
-		Run: func(cmd *cobra.Command, args []string) {
-			changelog := parser.Parse(iostreams.In)
-			changelog.Render(iostreams.Out)
+		Long: `Reformats changelog input following keepachangelog.com spec.
+
+The markdown style is read from the "format" section of the configuration
+and can be overridden with flags, eg. to match markdownlint rules.`,
+		Example: `  changelog fmt --wrap 80 --bullet '*' --blank-lines spaced`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			style, err := styleFromFlags(cmd)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			doc, err := readInputToRewrite(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+
+			err = doc.Render(cmd.Context(), iostreams.Out, changelog.WithStyle(style))
+			var styleErr *changelog.StyleError
+			if errors.As(err, &styleErr) {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("Invalid format: %s", styleErr.Err)
+			}
+			return err
 		},
 	}
+
+	fs := cmd.Flags()
+	fs.Int("wrap", 0, "Wrap items at this width, 0 disables wrapping")
+	fs.String("bullet", "", "List bullet: '-', '*' or '+'")
+	fs.String("emphasis", "", "Emphasis marker: '_' or '*'")
+	fs.String("blank-lines", "", "Blank lines after headings: 'compact' or 'spaced'")
+	fs.String("link-style", "", "Version links: 'reference' or 'inline'")
+	fs.String("date-format", "", "Layout of the release dates, eg. '2006-01-02' or '02/01/2006'")
+
+	return cmd
+}
+
+// styleFromFlags returns the configured style with the values of the
+// flags informed in the command line
+func styleFromFlags(cmd *cobra.Command) (chg.Style, error) {
+	fs := cmd.Flags()
+	style := chg.CurrentStyle()
+
+	var err error
+	if fs.Changed("wrap") {
+		style.Wrap, err = fs.GetInt("wrap")
+	}
+	if err == nil && fs.Changed("bullet") {
+		style.Bullet, err = fs.GetString("bullet")
+	}
+	if err == nil && fs.Changed("emphasis") {
+		style.Emphasis, err = fs.GetString("emphasis")
+	}
+	if err == nil && fs.Changed("blank-lines") {
+		style.BlankLines, err = fs.GetString("blank-lines")
+	}
+	if err == nil && fs.Changed("link-style") {
+		style.LinkStyle, err = fs.GetString("link-style")
+	}
+	if err == nil && fs.Changed("date-format") {
+		style.DateFormat, err = fs.GetString("date-format")
+	}
+	return style, err
 }
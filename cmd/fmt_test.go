@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/rcmachado/changelog/chg"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -72,3 +73,66 @@ and this project adheres to [Semantic Versioning](http://semver.org/spec/v2.0.0.
 	assert.Nil(t, err)
 	assert.Equal(t, expected, string(out.Bytes()))
 }
+
+func TestFmtCmdStyle(t *testing.T) {
+	changelog := `# Changelog
+
+## [Unreleased]
+### Added
+- A _long_ item that should be wrapped
+  by the formatter
+
+[Unreleased]: https://github.com/rcmachado/changelog/compare/0.1.0...HEAD
+`
+
+	expected := `# Changelog
+
+## [Unreleased](https://github.com/rcmachado/changelog/compare/0.1.0...HEAD)
+
+### Added
+
+* A *long* item that should be
+  wrapped by the formatter
+`
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  strings.NewReader(changelog),
+		Out: out,
+	}
+
+	fmt := newFmtCmd(iostreams)
+	fmt.SetArgs([]string{"--wrap", "32", "--bullet", "*", "--emphasis", "*", "--blank-lines", "spaced", "--link-style", "inline"})
+	_, err := fmt.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out.String())
+	assert.Equal(t, chg.DefaultStyle, chg.CurrentStyle())
+}
+
+func TestFmtCmdInvalidStyle(t *testing.T) {
+	iostreams := &IOStreams{
+		In:  strings.NewReader("# Changelog\n"),
+		Out: new(bytes.Buffer),
+	}
+
+	fmt := newFmtCmd(iostreams)
+	fmt.SetArgs([]string{"--bullet", "x"})
+	_, err := fmt.ExecuteC()
+
+	assert.EqualError(t, err, "Invalid format: invalid bullet 'x', use '-', '*' or '+'")
+}
+
+func TestFmtCmdIgnoredContent(t *testing.T) {
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  strings.NewReader("# Changelog\n\n## [Unreleased]\n### Performance\n- Faster parsing\n"),
+		Out: out,
+	}
+
+	fmt := newFmtCmd(iostreams)
+	_, err := fmt.ExecuteC()
+
+	assert.EqualError(t, err, "Refusing to rewrite the changelog, content would be lost: line 4: unknown change type 'Performance', its items are ignored")
+	assert.Empty(t, out.String())
+}
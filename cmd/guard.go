@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/git"
+	"github.com/rcmachado/changelog/lint"
+	"github.com/rcmachado/changelog/lockfile"
+	"github.com/spf13/cobra"
+)
+
+func newGuardCmd(iostreams *IOStreams, cfg *config.Config, repo *git.Repo) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "guard",
+		Short: "Protect released versions from modification",
+		Long: `Fail if the items, date or link of a released version changed.
+
+When the lockfile exists (see --lockfile), the released versions are
+compared with the hashes recorded by 'release'. Otherwise they are
+compared with the changelog committed at --base.
+
+Changes can be allowed with --allow and --reason. The justification is
+recorded in the lockfile, which is created if needed.`,
+		Example: `  # .git/hooks/pre-commit
+  changelog guard
+
+  changelog guard --allow 1.0.0 --reason "Fix broken link"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			fs := cmd.Flags()
+			base, _ := fs.GetString("base")
+			allowed, _ := fs.GetStringSlice("allow")
+			reason, _ := fs.GetString("reason")
+			filename, _ := fs.GetString("filename")
+			lockPath := lockfilePath(cmd, cfg)
+
+			if len(allowed) > 0 {
+				if strings.TrimSpace(reason) == "" {
+					return fmt.Errorf("--allow requires a --reason")
+				}
+				if lockPath == "" {
+					return fmt.Errorf("--allow requires a lockfile to record the justification")
+				}
+			}
+
+			doc, err := readInput(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+			changelog := doc.Changelog
+
+			var lock *lockfile.Lockfile
+			if lockPath != "" {
+				lock, err = lockfile.Load(lockPath)
+				if os.IsNotExist(err) {
+					lock, err = nil, nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			var problems []lint.Problem
+			if lock != nil {
+				problems = lock.Check(changelog)
+			} else {
+				content, err := repo.Show(base, filename)
+				if err != nil {
+					return err
+				}
+				released, err := parseChangelog(cmd.Context(), content)
+				if err != nil {
+					return fmt.Errorf("Failed to read changelog at %s: %s", base, err)
+				}
+				problems = releasedChanges(released, changelog)
+			}
+
+			var remaining []lint.Problem
+			for _, p := range problems {
+				if !containsFold(allowed, p.Version) {
+					remaining = append(remaining, p)
+				}
+			}
+
+			for _, p := range remaining {
+				fmt.Fprintln(iostreams.Out, p)
+			}
+			if len(remaining) > 0 {
+				return fmt.Errorf("Released versions were modified, use --allow to accept the changes")
+			}
+
+			if len(allowed) > 0 {
+				if lock == nil {
+					lock = &lockfile.Lockfile{}
+				}
+				today := time.Now().Format("2006-01-02")
+				for _, version := range allowed {
+					lock.Allow(version, today, reason)
+				}
+				lock.Update(changelog)
+				if err := lock.Save(lockPath); err != nil {
+					return err
+				}
+				fmt.Fprintf(iostreams.Out, "Recorded justification in '%s'\n", lockPath)
+			}
+
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.String("base", "HEAD", "Revision to compare with when there is no lockfile")
+	fs.String("lockfile", "", "Lockfile with the released versions hashes")
+	fs.StringSlice("allow", nil, "Released versions allowed to change")
+	fs.String("reason", "", "Justification for the allowed changes")
+
+	return cmd
+}
+
+// releasedChanges returns the changes to versions released in base
+func releasedChanges(base, changelog *chg.Changelog) []lint.Problem {
+	var problems []lint.Problem
+	for _, d := range chg.Diff(base, changelog) {
+		v := base.Version(d.Version)
+		if v == nil || v.IsUnreleased() {
+			continue
+		}
+
+		message := "released version was removed"
+		if d.Field != "" {
+			// "~ 1.0.0: date changed..." becomes "~ date changed..."
+			s := d.String()
+			message = s[:2] + strings.TrimPrefix(s[2:], d.Version+": ")
+		}
+		problems = append(problems, lint.Problem{Version: d.Version, Message: message})
+	}
+	return problems
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
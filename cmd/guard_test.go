@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/git"
+	"github.com/rcmachado/changelog/lockfile"
+	"github.com/rcmachado/changelog/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func executeGuardCmd(repo *git.Repo, cfg *config.Config, changelog string, args ...string) (string, error) {
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  strings.NewReader(changelog),
+		Out: out,
+	}
+
+	cmd := newGuardCmd(iostreams, cfg, repo)
+	cmd.Flags().String("filename", "CHANGELOG.md", "")
+	cmd.SetArgs(args)
+	_, err := cmd.ExecuteC()
+
+	return out.String(), err
+}
+
+func TestGuardCmdGit(t *testing.T) {
+	repo, cleanup := newTestGitRepo(t)
+	defer cleanup()
+
+	original := readChangelogString(t, repo)
+
+	t.Run("unreleased-changed", func(t *testing.T) {
+		changelog := strings.Replace(original, "- Item 4\n", "- Item 4\n- Item 5\n", 1)
+		out, err := executeGuardCmd(repo, config.Default(), changelog)
+		assert.NoError(t, err)
+		assert.Empty(t, out)
+	})
+
+	t.Run("released-changed", func(t *testing.T) {
+		changelog := strings.Replace(original, "- Item 3\n", "- Item 3\n- Item 5\n", 1)
+		changelog = strings.Replace(changelog, "2020-01-08", "2020-01-09", 1)
+
+		expected := `1.0.0: ~ date changed from "2020-01-08" to "2020-01-09"
+1.0.0: + Changed: Item 5
+`
+		out, err := executeGuardCmd(repo, config.Default(), changelog)
+		assert.Error(t, err)
+		assert.Equal(t, expected, out)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		changelog := strings.Replace(original, "## [1.0.0] - 2020-01-08\n", "## [\n", 1)
+		_, err := executeGuardCmd(repo, config.Default(), changelog)
+		assert.EqualError(t, err, "Failed to read changelog: reading changelog: line 12: invalid version heading '['")
+	})
+
+	t.Run("allow-requires-lockfile", func(t *testing.T) {
+		_, err := executeGuardCmd(repo, config.Default(), original, "--allow", "1.0.0", "--reason", "Typo")
+		assert.Error(t, err)
+	})
+}
+
+func TestGuardCmdLockfile(t *testing.T) {
+	repo, cleanup := newTestGitRepo(t)
+	defer cleanup()
+
+	original := readChangelogString(t, repo)
+	lockPath := filepath.Join(repo.Dir, ".changelog.lock")
+	lockfile.New(parser.Parse(strings.NewReader(original))).Save(lockPath)
+
+	cfg := config.Default()
+	cfg.Guard.Lockfile = lockPath
+
+	changelog := strings.Replace(original, "- Item 3\n", "- Item 3 (fixed typo)\n", 1)
+
+	t.Run("modified", func(t *testing.T) {
+		out, err := executeGuardCmd(repo, cfg, changelog)
+		assert.Error(t, err)
+		assert.Equal(t, "1.0.0: released version was modified\n", out)
+	})
+
+	t.Run("allow-requires-reason", func(t *testing.T) {
+		_, err := executeGuardCmd(repo, cfg, changelog, "--allow", "1.0.0")
+		assert.Error(t, err)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		out, err := executeGuardCmd(repo, cfg, changelog, "--allow", "1.0.0", "--reason", "Fix typo")
+		assert.NoError(t, err)
+		assert.Equal(t, "Recorded justification in '"+lockPath+"'\n", out)
+
+		content, _ := ioutil.ReadFile(lockPath)
+		assert.Contains(t, string(content), "# allowed 1.0.0 on ")
+		assert.Contains(t, string(content), ": Fix typo\n")
+
+		// The new content is now locked
+		_, err = executeGuardCmd(repo, cfg, changelog)
+		assert.NoError(t, err)
+	})
+}
@@ -3,7 +3,7 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/changelog"
 	"github.com/spf13/cobra"
 )
 
@@ -18,8 +18,8 @@ You can specify a filename using the --output/-o flag.`,
 			fs := cmd.Flags()
 			compareURL, _ := fs.GetString("compare-url")
 
-			c := chg.NewEmptyChangelog(compareURL)
-			c.Render(iostreams.Out)
+			doc := changelog.New(changelog.WithCompareURL(compareURL))
+			doc.Render(cmd.Context(), iostreams.Out)
 
 			destination, _ := fs.GetString("output")
 			if destination != "-" {
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rcmachado/changelog/changelog"
+	"github.com/spf13/cobra"
+)
+
+func newLintCmd(iostreams *IOStreams) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint",
+		Short: "Check the changelog for problems",
+		Long:  "Checks the changelog against keepachangelog.com conventions, exiting with an error if any problem is found",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			doc, err := readInput(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+
+			var invalid *changelog.ValidationError
+			if !errors.As(doc.Validate(), &invalid) {
+				return nil
+			}
+
+			for _, p := range invalid.Problems {
+				fmt.Fprintln(iostreams.Out, p)
+			}
+			cmd.SilenceUsage = true
+			return fmt.Errorf("Found %d problem(s)", len(invalid.Problems))
+		},
+	}
+}
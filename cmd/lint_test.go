@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintCmd(t *testing.T) {
+	changelog, err := ioutil.ReadFile("testdata/show-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	cmd := newLintCmd(iostreams)
+	_, err = cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Empty(t, out.String())
+}
+
+func TestLintCmdProblems(t *testing.T) {
+	changelog := `# Changelog
+
+## 1.0.0
+### Added
+- Item 1
+`
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  strings.NewReader(changelog),
+		Out: out,
+	}
+
+	cmd := newLintCmd(iostreams)
+	_, err := cmd.ExecuteC()
+
+	assert.Error(t, err)
+	assert.Equal(t, "missing Unreleased version\n1.0.0: missing release date\n", out.String())
+}
+
+func TestLintCmdIgnoredContent(t *testing.T) {
+	changelog := `# Changelog
+
+## [Unreleased]
+### Performance
+- Faster parsing
+`
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  strings.NewReader(changelog),
+		Out: out,
+	}
+
+	cmd := newLintCmd(iostreams)
+	_, err := cmd.ExecuteC()
+
+	assert.Error(t, err)
+	assert.Equal(t, "line 4: unknown change type 'Performance', its items are ignored\n", out.String())
+}
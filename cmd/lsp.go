@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/rcmachado/changelog/lsp"
+	"github.com/spf13/cobra"
+)
+
+func newLspCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Run a language server for changelog files",
+		Long: `Runs a Language Server Protocol server over stdin/stdout, providing
+diagnostics, outline, completion of change types, formatting and quick fixes
+to editors`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{annotationNoInput: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Responses can't wait for the buffered output to be flushed
+			// at the end of the command, so the server uses the standard
+			// streams directly
+			return lsp.NewServer(cmd.InOrStdin(), cmd.OutOrStdout()).Run()
+		},
+	}
+
+	// Accepted for compatibility with clients that pass it, stdio is
+	// the only transport available
+	cmd.Flags().Bool("stdio", true, "Use stdin/stdout to communicate (default)")
+
+	return cmd
+}
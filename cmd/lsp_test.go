@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLspCmd(t *testing.T) {
+	in := new(bytes.Buffer)
+	for _, msg := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	} {
+		fmt.Fprintf(in, "Content-Length: %d\r\n\r\n%s", len(msg), msg)
+	}
+	out := new(bytes.Buffer)
+
+	cmd := newLspCmd()
+	cmd.SetArgs([]string{"--stdio"})
+	cmd.SetIn(in)
+	cmd.SetOut(out)
+	_, err := cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), `"capabilities"`)
+	assert.Contains(t, out.String(), `{"id":2,"jsonrpc":"2.0","result":null}`)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/rcmachado/changelog/migrate"
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCmd(iostreams *IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Convert a changelog from other styles",
+		Long: `Convert a changelog written in other style to the keepachangelog.com
+format. Items without a section are classified by keywords (eg. "Fix:",
+"Added ...") and the ones whose change type was guessed are reported.
+
+Styles:
+  markdown  version headings like "## v1.2.0 (2020-01-01)"
+  github    release notes generated by GitHub ("What's Changed")
+  rst       reStructuredText, like CHANGES.rst`,
+		Example: `  changelog migrate -f HISTORY.md -o CHANGELOG.md
+  changelog migrate --from rst -f CHANGES.rst -o CHANGELOG.md`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			from, _ := cmd.Flags().GetString("from")
+			strict, _ := cmd.Flags().GetBool("strict")
+
+			content, err := ioutil.ReadAll(iostreams.In)
+			if err != nil {
+				return fmt.Errorf("Failed to read changelog: %s", err)
+			}
+
+			changelog, uncertain, err := migrate.Migrate(content, from)
+			if err != nil {
+				return err
+			}
+
+			if len(uncertain) > 0 {
+				errOut := cmd.ErrOrStderr()
+				fmt.Fprintf(errOut, "Check the change type of %d items:\n", len(uncertain))
+				for _, u := range uncertain {
+					fmt.Fprintf(errOut, "  %s\n", u)
+				}
+				if strict {
+					return fmt.Errorf("Found %d items with uncertain change type", len(uncertain))
+				}
+			}
+
+			changelog.Render(iostreams.Out)
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.String("from", "auto", "Style of the changelog: auto, "+strings.Join(migrate.Names(), ", "))
+	fs.Bool("strict", false, "Fail when the change type of some item was guessed")
+
+	return cmd
+}
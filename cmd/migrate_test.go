@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const legacyChangelog = `# History
+
+## v1.1.0 (2020-01-01)
+* Fix: crash on start
+* Handle empty input
+`
+
+func TestMigrateCmd(t *testing.T) {
+	expected := `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.0.0/)
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+
+## Unreleased
+
+## 1.1.0 - 2020-01-01
+### Changed
+- Handle empty input
+
+### Fixed
+- Crash on start
+`
+
+	out := new(bytes.Buffer)
+	errOut := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  strings.NewReader(legacyChangelog),
+		Out: out,
+	}
+
+	cmd := newMigrateCmd(iostreams)
+	cmd.SetErr(errOut)
+	cmd.SetArgs([]string{})
+	_, err := cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out.String())
+	assert.Equal(t, "Check the change type of 1 items:\n  1.1.0: 'Handle empty input' classified as Changed (no keyword found)\n", errOut.String())
+}
+
+func TestMigrateCmdStrict(t *testing.T) {
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  strings.NewReader(legacyChangelog),
+		Out: out,
+	}
+
+	cmd := newMigrateCmd(iostreams)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--strict"})
+	_, err := cmd.ExecuteC()
+
+	assert.EqualError(t, err, "Found 1 items with uncertain change type")
+	assert.Empty(t, out.String())
+}
+
+func TestMigrateCmdUnknownStyle(t *testing.T) {
+	iostreams := &IOStreams{
+		In:  strings.NewReader(legacyChangelog),
+		Out: new(bytes.Buffer),
+	}
+
+	cmd := newMigrateCmd(iostreams)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--from", "textile"})
+	_, err := cmd.ExecuteC()
+
+	assert.EqualError(t, err, "Unknown importer: 'textile'")
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/rcmachado/changelog/changelog"
+	"github.com/rcmachado/changelog/parser"
+	"github.com/spf13/cobra"
+)
+
+func newPreambleCmd(iostreams *IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preamble",
+		Short: "Show or change the text before the versions",
+		Long: `Show or change the preamble, the markdown between the title and the
+first version, and the title of the changelog.`,
+	}
+
+	cmd.AddCommand(
+		newPreambleGetCmd(iostreams),
+		newPreambleSetCmd(iostreams),
+	)
+
+	return cmd
+}
+
+func newPreambleGetCmd(iostreams *IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Show the preamble",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			doc, err := readInput(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+
+			text := doc.Preamble
+			if title, _ := cmd.Flags().GetBool("title"); title {
+				text = doc.Title
+			}
+			if text != "" {
+				fmt.Fprintln(iostreams.Out, text)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("title", false, "Show the title instead")
+
+	return cmd
+}
+
+func newPreambleSetCmd(iostreams *IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set [text]",
+		Short: "Replace the preamble",
+		Long: `Replace the preamble with the markdown text informed as argument or read
+from --file ('-' for stdin). Use --title to change the title line.`,
+		Example: `  changelog preamble set --file docs/changelog-intro.md -o CHANGELOG.md
+  changelog preamble set --title "Release notes"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			fs := cmd.Flags()
+			file, _ := fs.GetString("file")
+			title, _ := fs.GetString("title")
+
+			if len(args) > 0 && file != "" {
+				return fmt.Errorf("Inform the preamble as argument or with --file, not both")
+			}
+			if len(args) == 0 && file == "" && !fs.Changed("title") {
+				return fmt.Errorf("Nothing to set: inform the preamble text, --file or --title")
+			}
+
+			doc, err := readInputToRewrite(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+
+			if fs.Changed("title") {
+				doc.Title = strings.TrimSpace(title)
+			}
+			switch {
+			case len(args) > 0:
+				doc.Preamble = strings.TrimSpace(args[0])
+			case file == "-":
+				content, err := ioutil.ReadAll(cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("Failed to read preamble: %s", err)
+				}
+				doc.Preamble = strings.TrimSpace(string(content))
+			case file != "":
+				content, err := ioutil.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("Failed to read preamble: %s", err)
+				}
+				doc.Preamble = strings.TrimSpace(string(content))
+			}
+
+			if !readsBack(doc) {
+				return fmt.Errorf("Invalid preamble: level 2 headings start the versions, use level 3 or lower")
+			}
+			return doc.Render(cmd.Context(), iostreams.Out)
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.String("file", "", "Read the preamble from the file, '-' for stdin")
+	fs.String("title", "", "Title of the changelog, eg. \"Release notes\"")
+
+	return cmd
+}
+
+// readsBack checks if the changelog is parsed back with the same title,
+// preamble and versions. Level 2 headings in the preamble, including
+// the setext ones ("Heading\n---"), would be read as versions.
+func readsBack(doc *changelog.Document) bool {
+	c, err := parser.Read(bytes.NewReader(doc.Bytes()))
+	return err == nil && c.Title == doc.Title && c.Preamble == doc.Preamble && len(c.Versions) == len(doc.Versions)
+}
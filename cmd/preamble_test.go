@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const preambleChangelog = `# Changelog
+
+Intro with a list:
+
+- One
+- Two
+
+## [Unreleased]
+### Added
+- Item
+`
+
+func TestPreambleGetCmd(t *testing.T) {
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{In: strings.NewReader(preambleChangelog), Out: out}
+
+	cmd := newPreambleCmd(iostreams)
+	cmd.SetArgs([]string{"get"})
+	_, err := cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Intro with a list:\n\n- One\n- Two\n", out.String())
+
+	out.Reset()
+	iostreams.In = strings.NewReader(preambleChangelog)
+	cmd.SetArgs([]string{"get", "--title"})
+	_, err = cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Changelog\n", out.String())
+}
+
+func TestPreambleSetCmd(t *testing.T) {
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{In: strings.NewReader(preambleChangelog), Out: out}
+
+	cmd := newPreambleCmd(iostreams)
+	cmd.SetArgs([]string{"set", "--title", "Release notes", "New intro"})
+	_, err := cmd.ExecuteC()
+
+	expected := `# Release notes
+
+New intro
+
+## Unreleased
+### Added
+- Item
+`
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out.String())
+}
+
+func TestPreambleSetCmdVersionHeading(t *testing.T) {
+	for _, preamble := range []string{"Intro\n\n## How to read this", "Intro\n\nHow to read this\n---"} {
+		out := new(bytes.Buffer)
+		iostreams := &IOStreams{In: strings.NewReader(preambleChangelog), Out: out}
+
+		cmd := newPreambleCmd(iostreams)
+		cmd.SetArgs([]string{"set", preamble})
+		_, err := cmd.ExecuteC()
+
+		assert.EqualError(t, err, "Invalid preamble: level 2 headings start the versions, use level 3 or lower")
+		assert.Empty(t, out.String())
+	}
+}
+
+func TestPreambleSetCmdFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "changelog-preamble")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "intro.md")
+	ioutil.WriteFile(file, []byte("```sh\nmake\n```\n"), 0644)
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{In: strings.NewReader(preambleChangelog), Out: out}
+
+	cmd := newPreambleCmd(iostreams)
+	cmd.SetArgs([]string{"set", "--file", file})
+	_, err = cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "# Changelog\n\n```sh\nmake\n```\n\n## Unreleased\n")
+}
+
+func TestPreambleSetCmdNothing(t *testing.T) {
+	iostreams := &IOStreams{In: strings.NewReader(preambleChangelog), Out: new(bytes.Buffer)}
+
+	cmd := newPreambleCmd(iostreams)
+	cmd.SetArgs([]string{"set"})
+	_, err := cmd.ExecuteC()
+
+	assert.EqualError(t, err, "Nothing to set: inform the preamble text, --file or --title")
+}
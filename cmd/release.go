@@ -2,57 +2,186 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/rcmachado/changelog/changelog"
 	"github.com/rcmachado/changelog/chg"
-	"github.com/rcmachado/changelog/parser"
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/lockfile"
 	"github.com/spf13/cobra"
 )
 
-func newReleaseCmd(iostreams *IOStreams) *cobra.Command {
-
-	const dateFormat = "2006-01-02"
-
+func newReleaseCmd(iostreams *IOStreams, cfg *config.Config) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "release [version]",
 		Short: "Change Unreleased to [version]",
 		Long: `Change Unreleased section to [version], updating the compare links accordingly.
 It will normalize the output with the new version.
+
+When a lockfile is configured (see --lockfile), the hash of the new
+version is added to it, so 'guard' can detect changes to it. The other
+hashes are kept: the release fails if a released version changed since
+the lockfile was updated, until the change is accepted with 'guard'.
+
+The release date accepts "today", "yesterday" or a date in one of the
+formats recognized when parsing (eg. 2024-01-31, 31/01/2024 or
+"January 31, 2024"). "today" and "yesterday" use the --timezone.
+
+With --promote, the items of the pre-releases of the version (eg.
+2.0.0-rc.1 and 2.0.0-rc.2 of 2.0.0) are gathered into it, without
+duplicates, and the pre-releases are removed. With --keep-prereleases,
+they're kept and marked as [SUPERSEDED] instead.
 `,
+		Example: `  changelog release 1.2.0 -o CHANGELOG.md
+  changelog release 2.0.0 --promote -o CHANGELOG.md`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fs := cmd.Flags()
 
 			releaseDate, _ := fs.GetString("release-date")
+			timezone, _ := fs.GetString("timezone")
 			compareURL, _ := fs.GetString("compare-url")
+			promote, _ := fs.GetBool("promote")
+			keep, _ := fs.GetBool("keep-prereleases")
 
-			version := chg.Version{
-				Name: args[0],
-				Date: releaseDate,
+			if keep && !promote {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("--keep-prereleases requires --promote")
 			}
 
-			if compareURL != "" {
-				version.Link = compareURL
+			releaseDate, err := parseReleaseDate(releaseDate, timezone, time.Now())
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
 			}
 
-			changelog := parser.Parse(iostreams.In)
+			doc, err := readInputToRewrite(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+			edit, err := startLockedEdit(cmd, lockfilePath(cmd, cfg), doc.Changelog)
+			if err != nil {
+				return err
+			}
 
-			_, err := changelog.Release(version)
+			opts := []changelog.Option{changelog.WithDate(releaseDate), changelog.WithCompareURL(compareURL)}
+			if promote {
+				opts = append(opts, changelog.WithPromote(keep))
+			}
+			_, err = doc.Release(args[0], opts...)
 			if err != nil {
 				cmd.SilenceUsage = true
 				return fmt.Errorf("Failed to create release '%s': %s\n", args[0], err)
 			}
 
-			changelog.Render(iostreams.Out)
-			return nil
+			if err := doc.Render(cmd.Context(), iostreams.Out); err != nil {
+				return err
+			}
+
+			return edit.finish(cmd, doc.Changelog)
 		},
 	}
 
 	fs := cmd.Flags()
 
-	today := time.Now().Format(dateFormat)
-	fs.StringP("release-date", "d", today, "Release date")
+	fs.StringP("release-date", "d", "today", "Release date: 'today', 'yesterday' or a date, eg. 2024-01-31")
+	fs.String("timezone", "Local", "Timezone of 'today' and 'yesterday', eg. UTC or America/Sao_Paulo")
 	fs.StringP("compare-url", "c", "", "Overwrite compare URL for Unreleased section")
+	fs.String("lockfile", "", "Lockfile to update with the released versions hashes")
+	fs.Bool("promote", false, "Gather the items of the pre-releases of the version into it")
+	fs.Bool("keep-prereleases", false, "Keep the promoted pre-releases, marked as superseded")
 
 	return cmd
 }
+
+// parseReleaseDate returns the release date in the ISO format. Besides
+// the formats accepted by chg.ParseDate, it accepts "today" and
+// "yesterday", relative to now in the timezone.
+func parseReleaseDate(value, timezone string, now time.Time) (string, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", fmt.Errorf("Invalid timezone '%s': %s", timezone, err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "today":
+		return now.In(loc).Format(chg.ISODate), nil
+	case "yesterday":
+		return now.In(loc).AddDate(0, 0, -1).Format(chg.ISODate), nil
+	}
+
+	t, err := chg.ParseDate(value)
+	if err != nil {
+		return "", fmt.Errorf("Invalid release date '%s', use 'today', 'yesterday' or a date like 2024-01-31", value)
+	}
+	return t.Format(chg.ISODate), nil
+}
+
+// lockfilePath returns the lockfile informed with --lockfile or in the
+// configuration, empty when there is none
+func lockfilePath(cmd *cobra.Command, cfg *config.Config) string {
+	if lockPath, _ := cmd.Flags().GetString("lockfile"); lockPath != "" {
+		return lockPath
+	}
+	return cfg.Guard.Lockfile
+}
+
+// lockedEdit keeps the lockfile of a command in sync with its edit of
+// the changelog
+type lockedEdit struct {
+	path   string
+	lock   *lockfile.Lockfile // nil when the lockfile doesn't exist yet
+	before map[string]string
+}
+
+// startLockedEdit loads the lockfile, if there is one, before the edit.
+// It fails when released versions were modified since the lockfile was
+// updated, as updating it would hide those changes from 'guard'.
+func startLockedEdit(cmd *cobra.Command, lockPath string, changelog *chg.Changelog) (*lockedEdit, error) {
+	if lockPath == "" {
+		return nil, nil
+	}
+
+	lock, err := lockfile.Load(lockPath)
+	if os.IsNotExist(err) {
+		lock, err = nil, nil
+	}
+	if err != nil {
+		cmd.SilenceUsage = true
+		return nil, fmt.Errorf("Failed to read lockfile '%s': %s", lockPath, err)
+	}
+
+	if lock != nil {
+		if problems := lock.Check(changelog); len(problems) > 0 {
+			messages := make([]string, len(problems))
+			for idx, p := range problems {
+				messages[idx] = p.String()
+			}
+			cmd.SilenceUsage = true
+			return nil, fmt.Errorf("Released versions changed since '%s' was updated, check them with 'guard': %s", lockPath, strings.Join(messages, "; "))
+		}
+	}
+
+	return &lockedEdit{path: lockPath, lock: lock, before: lockfile.Hashes(changelog)}, nil
+}
+
+// finish updates the hashes of the versions changed by the edit, or
+// creates the lockfile with all of them
+func (e *lockedEdit) finish(cmd *cobra.Command, changelog *chg.Changelog) error {
+	if e == nil {
+		return nil
+	}
+
+	if e.lock == nil {
+		e.lock = lockfile.New(changelog)
+	} else {
+		e.lock.Sync(e.before, changelog)
+	}
+	if err := e.lock.Save(e.path); err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("Failed to update lockfile '%s': %s", e.path, err)
+	}
+	return nil
+}
@@ -3,8 +3,15 @@ package cmd
 import (
 	"bytes"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/lockfile"
+	"github.com/rcmachado/changelog/parser"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -37,7 +44,7 @@ and this project adheres to [Semantic Versioning](http://semver.org/spec/v2.0.0.
 		Out: out,
 	}
 
-	release := newReleaseCmd(iostreams)
+	release := newReleaseCmd(iostreams, config.Default())
 	release.SetArgs([]string{"0.1.0", "--release-date", "2018-06-18", "--compare-url", "https://example.com/<prev>/<next>"})
 	_, err = release.ExecuteC()
 
@@ -56,7 +63,7 @@ func TestReleaseCmdError(t *testing.T) {
 		Out: new(bytes.Buffer),
 	}
 
-	release := newReleaseCmd(iostreams)
+	release := newReleaseCmd(iostreams, config.Default())
 	// Missing --compare-url, as the autodetect won't work for the minimal changelog
 	release.SetArgs([]string{"0.1.0", "--release-date", "2018-06-18"})
 	_, err = release.ExecuteC()
@@ -93,7 +100,7 @@ and this project adheres to [Semantic Versioning](http://semver.org/spec/v2.0.0.
 		Out: out,
 	}
 
-	release := newReleaseCmd(iostreams)
+	release := newReleaseCmd(iostreams, config.Default())
 	// Missing --compare-url, as the autodetect won't work for the minimal changelog
 	release.SetArgs([]string{"0.1.0", "--release-date", "2018-06-18"})
 	_, err = release.ExecuteC()
@@ -101,3 +108,177 @@ and this project adheres to [Semantic Versioning](http://semver.org/spec/v2.0.0.
 	assert.Nil(t, err)
 	assert.Equal(t, expected, string(out.Bytes()))
 }
+
+func TestReleaseCmdLockfile(t *testing.T) {
+	changelog, err := ioutil.ReadFile("testdata/minimal-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "changelog-release")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	lockPath := filepath.Join(dir, ".changelog.lock")
+	cfg := config.Default()
+	cfg.Guard.Lockfile = lockPath
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	release := newReleaseCmd(iostreams, cfg)
+	release.SetArgs([]string{"0.1.0", "--release-date", "2018-06-18"})
+	_, err = release.ExecuteC()
+	assert.Nil(t, err)
+
+	lock, err := lockfile.Load(lockPath)
+	assert.Nil(t, err)
+
+	released := parser.Parse(out).Version("0.1.0")
+	assert.Equal(t, []lockfile.Entry{{Version: "0.1.0", Hash: released.Hash()}}, lock.Versions)
+}
+
+func TestReleaseCmdLockfileModified(t *testing.T) {
+	changelog, err := ioutil.ReadFile("testdata/show-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "changelog-release")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	lockPath := filepath.Join(dir, ".changelog.lock")
+	lockfile.New(parser.ParseBytes(changelog)).Save(lockPath)
+	original, _ := ioutil.ReadFile(lockPath)
+
+	cfg := config.Default()
+	cfg.Guard.Lockfile = lockPath
+
+	run := func(input string) (string, error) {
+		out := new(bytes.Buffer)
+		iostreams := &IOStreams{
+			In:  strings.NewReader(input),
+			Out: out,
+		}
+
+		release := newReleaseCmd(iostreams, cfg)
+		release.SetArgs([]string{"1.1.0", "--release-date", "2020-02-01"})
+		_, err := release.ExecuteC()
+		return out.String(), err
+	}
+
+	modified := strings.Replace(string(changelog), "- Item 3\n", "- Item 3 (edited)\n", 1)
+	out, err := run(modified)
+	assert.EqualError(t, err, "Released versions changed since '"+lockPath+"' was updated, check them with 'guard': 1.0.0: released version was modified")
+	assert.Empty(t, out)
+	content, _ := ioutil.ReadFile(lockPath)
+	assert.Equal(t, string(original), string(content))
+
+	out, err = run(string(changelog))
+	assert.NoError(t, err)
+	lock, _ := lockfile.Load(lockPath)
+	assert.Empty(t, lock.Check(parser.Parse(strings.NewReader(out))))
+	assert.Len(t, lock.Versions, 2)
+}
+
+func TestParseReleaseDate(t *testing.T) {
+	now := time.Date(2024, 3, 1, 1, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value, timezone, expected string
+	}{
+		{"today", "UTC", "2024-03-01"},
+		{"yesterday", "UTC", "2024-02-29"},
+		{"Today", "America/Sao_Paulo", "2024-02-29"},
+		{"31/01/2024", "UTC", "2024-01-31"},
+		{"January 31, 2024", "UTC", "2024-01-31"},
+	}
+	for _, test := range tests {
+		date, err := parseReleaseDate(test.value, test.timezone, now)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, date, test.value)
+	}
+
+	_, err := parseReleaseDate("tomorrow", "UTC", now)
+	assert.EqualError(t, err, "Invalid release date 'tomorrow', use 'today', 'yesterday' or a date like 2024-01-31")
+
+	_, err = parseReleaseDate("today", "Mars/Olympus", now)
+	assert.Error(t, err)
+}
+
+func TestReleaseCmdPromote(t *testing.T) {
+	changelog, err := ioutil.ReadFile("testdata/prerelease-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# Changelog
+
+## [Unreleased]
+
+## [2.0.0] - 2020-03-01
+### Added
+- Feature
+
+### Fixed
+- Bug in the feature
+- Crash on start
+
+## [1.0.0] - 2020-01-01
+### Added
+- First release
+
+[Unreleased]: https://example.com/compare/2.0.0...HEAD
+[2.0.0]: https://example.com/compare/1.0.0...2.0.0
+[1.0.0]: https://example.com/releases/tag/1.0.0
+`
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	release := newReleaseCmd(iostreams, config.Default())
+	release.SetArgs([]string{"2.0.0", "--release-date", "2020-03-01", "--promote"})
+	_, err = release.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out.String())
+}
+
+func TestReleaseCmdPromoteKeepPrereleases(t *testing.T) {
+	changelog, err := ioutil.ReadFile("testdata/prerelease-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	release := newReleaseCmd(iostreams, config.Default())
+	release.SetArgs([]string{"2.0.0", "--release-date", "2020-03-01", "--promote", "--keep-prereleases"})
+	_, err = release.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "## [2.0.0-rc.2] - 2020-02-15 [SUPERSEDED]\n")
+	assert.Contains(t, out.String(), "## [2.0.0-rc.1] - 2020-02-01 [SUPERSEDED]\n")
+	assert.Contains(t, out.String(), "[2.0.0]: https://example.com/compare/2.0.0-rc.2...2.0.0\n")
+
+	release = newReleaseCmd(&IOStreams{In: bytes.NewBuffer(changelog), Out: new(bytes.Buffer)}, config.Default())
+	release.SetArgs([]string{"2.0.0", "--keep-prereleases"})
+	_, err = release.ExecuteC()
+
+	assert.EqualError(t, err, "--keep-prereleases requires --promote")
+}
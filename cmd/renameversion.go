@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/spf13/cobra"
+)
+
+func newRenameVersionCmd(iostreams *IOStreams, cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename-version <old> <new>",
+		Short: "Rename a released version",
+		Long: `Rename a released version, updating its heading, its compare link and
+the link of the newer version, which compares with it.
+
+When the versions follow semantic versioning, the new name has to stay
+between the versions around it, eg. 1.3.0 can become 1.3.0-beta.1 if
+the previous release is 1.2.0. The lockfile (see 'release') is updated
+accordingly.`,
+		Example: `  changelog rename-version 1.3.0 1.3.0-beta.1 -o CHANGELOG.md`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			doc, err := readInputToRewrite(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+			edit, err := startLockedEdit(cmd, lockfilePath(cmd, cfg), doc.Changelog)
+			if err != nil {
+				return err
+			}
+
+			if _, err := doc.RenameVersion(args[0], args[1]); err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("Failed to rename '%s': %s", args[0], err)
+			}
+
+			if err := doc.Render(cmd.Context(), iostreams.Out); err != nil {
+				return err
+			}
+
+			return edit.finish(cmd, doc.Changelog)
+		},
+	}
+
+	cmd.Flags().String("lockfile", "", "Lockfile to update with the released versions hashes")
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func executeRenameVersionCmd(t *testing.T, args ...string) (string, error) {
+	changelog, err := ioutil.ReadFile("testdata/search-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	cmd := newRenameVersionCmd(iostreams, config.Default())
+	cmd.SetArgs(args)
+	_, err = cmd.ExecuteC()
+
+	return out.String(), err
+}
+
+func TestRenameVersionCmd(t *testing.T) {
+	out, err := executeRenameVersionCmd(t, "1.1.0", "1.1.0-beta.1")
+
+	assert.Nil(t, err)
+	assert.Contains(t, out, "## [1.1.0-beta.1] - 2020-02-01\n")
+	assert.Contains(t, out, `[Unreleased]: https://github.com/rcmachado/changelog/compare/1.1.0-beta.1...HEAD
+[1.1.0-beta.1]: https://github.com/rcmachado/changelog/compare/1.0.0...1.1.0-beta.1
+[1.0.0]: https://github.com/rcmachado/changelog/releases/tag/1.0.0
+`)
+}
+
+func TestRenameVersionCmdError(t *testing.T) {
+	out, err := executeRenameVersionCmd(t, "1.1.0", "0.9.0")
+
+	assert.EqualError(t, err, "Failed to rename '1.1.0': '0.9.0' has to follow the older version '1.0.0'")
+	assert.Empty(t, out)
+}
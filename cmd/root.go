@@ -4,18 +4,32 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"strings"
 
+	"github.com/rcmachado/changelog/changelog"
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/git"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
 var ioStreams *IOStreams
 
+// appConfig is filled by Execute, before running the commands
+var appConfig *config.Config
+
+// annotationNoInput marks commands that don't read the changelog from --filename
+const annotationNoInput = "changelog/no-input"
+
 // IOStreams holds input/output streams for commands
 type IOStreams struct {
 	In  io.Reader
 	Out io.Writer
+	// OutTerminal is set when Out writes to a terminal
+	OutTerminal bool
 }
 
 var rootCmd = &cobra.Command{
@@ -25,17 +39,69 @@ var rootCmd = &cobra.Command{
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		fs := cmd.Flags()
 
-		fdr := openFileOrExit(fs, "filename", os.O_RDONLY, os.Stdin)
-		ioStreams.In = bufio.NewReader(fdr)
+		if !hasAnnotation(cmd, annotationNoInput) {
+			fdr := openFileOrExit(fs, "filename", os.O_RDONLY, os.Stdin)
+			ioStreams.In = bufio.NewReader(fdr)
+		}
 
 		fdw := openFileOrExit(fs, "output", os.O_WRONLY|os.O_CREATE, os.Stdout)
 		ioStreams.Out = bufio.NewWriter(fdw)
+		ioStreams.OutTerminal = isTerminal(fdw)
 	},
-	PersistentPostRun: func(cmd *cobra.Command, args []string) {
-		if ioStreams.Out != nil {
-			ioStreams.Out.(*bufio.Writer).Flush()
+}
+
+// executeRoot runs the command and flushes its output. It's done here
+// instead of in PersistentPostRun, which cobra skips when the command
+// fails, losing what the command printed before failing.
+func executeRoot() error {
+	err := rootCmd.Execute()
+	if out, ok := ioStreams.Out.(*bufio.Writer); ok {
+		if flushErr := out.Flush(); err == nil {
+			err = flushErr
 		}
-	},
+	}
+	return err
+}
+
+// hasAnnotation checks if cmd or one of its parents has the annotation
+func hasAnnotation(cmd *cobra.Command, annotation string) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[annotation]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// readInput parses the changelog of the input
+func readInput(cmd *cobra.Command, iostreams *IOStreams) (*changelog.Document, error) {
+	doc, err := changelog.Read(cmd.Context(), iostreams.In)
+	if err != nil {
+		cmd.SilenceUsage = true
+		return nil, fmt.Errorf("Failed to read changelog: %s", err)
+	}
+	return doc, nil
+}
+
+// readInputToRewrite parses the changelog of the input for the commands
+// that write it back. It fails when the parser ignored part of the
+// input, as that content would be lost.
+func readInputToRewrite(cmd *cobra.Command, iostreams *IOStreams) (*changelog.Document, error) {
+	doc, err := readInput(cmd, iostreams)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.Rewritable(); err != nil {
+		cmd.SilenceUsage = true
+		return nil, fmt.Errorf("Refusing to rewrite the changelog, %s", err)
+	}
+	return doc, nil
+}
+
+// isTerminal checks if the file is a terminal (a character device)
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
 
 func openFileOrExit(fs *pflag.FlagSet, option string, flag int, defaultIfDash *os.File) *os.File {
@@ -59,29 +125,95 @@ func openFileOrExit(fs *pflag.FlagSet, option string, flag int, defaultIfDash *o
 
 func init() {
 	ioStreams = &IOStreams{}
+	appConfig = config.Default()
 
 	rootCmd.AddCommand(
 		newInitCmd(ioStreams),
+		newAddCmd(ioStreams, runEditor),
 		newFmtCmd(ioStreams),
-		newReleaseCmd(ioStreams),
+		newLintCmd(ioStreams),
+		newDiffCmd(ioStreams),
+		newReleaseCmd(ioStreams, appConfig),
+		newUnreleaseCmd(ioStreams, appConfig),
+		newRenameVersionCmd(ioStreams, appConfig),
 		newShowCmd(ioStreams),
+		newPreambleCmd(ioStreams),
+		newMigrateCmd(ioStreams),
+		newSearchCmd(ioStreams),
+		newStatsCmd(ioStreams),
+		newUnreleasedCmd(ioStreams),
+		newWorkspaceCmd(ioStreams, appConfig),
+		newAggregateCmd(ioStreams, appConfig),
+		newCheckPRCmd(ioStreams, appConfig, &git.Repo{}),
+		newGuardCmd(ioStreams, appConfig, &git.Repo{}),
+		newLspCmd(),
+		newServeCmd(appConfig, http.ListenAndServe),
+		newWatchCmd(ioStreams, appConfig),
 	)
 
-	manipulationCmds := newChangeTypeCmds(ioStreams)
-	for _, cmd := range manipulationCmds {
-		rootCmd.AddCommand(cmd)
-	}
-
 	flags := rootCmd.PersistentFlags()
+	flags.String("config", config.DefaultFilename, "Configuration file")
+	rootCmd.MarkFlagFilename("config")
 	flags.StringP("filename", "f", "CHANGELOG.md", "Changelog file or '-' for stdin")
 	rootCmd.MarkFlagFilename("filename")
 	flags.StringP("output", "o", "-", "Output file or '-' for stdout")
 	rootCmd.MarkFlagFilename("output")
 }
 
+// configFromArgs loads the configuration informed by --config.
+// It needs to run before cobra parses the flags, as the configuration
+// defines which commands are available.
+func configFromArgs(args []string) (*config.Config, error) {
+	filename := config.DefaultFilename
+	optional := true
+
+	for idx, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "--config=") {
+			filename = strings.TrimPrefix(arg, "--config=")
+			optional = false
+		} else if arg == "--config" && idx+1 < len(args) {
+			filename = args[idx+1]
+			optional = false
+		}
+	}
+
+	return config.Load(filename, optional)
+}
+
 // Execute the program with command-line args
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	cfg, err := configFromArgs(os.Args[1:])
+	if err != nil {
+		fmt.Printf("Failed to load configuration: %s\n", err)
+		os.Exit(2)
+	}
+
+	if err := chg.SetChangeTypes(cfg.ChangeTypeDefs()); err != nil {
+		fmt.Printf("Invalid change types: %s\n", err)
+		os.Exit(2)
+	}
+
+	if err := chg.SetStyle(cfg.Style()); err != nil {
+		fmt.Printf("Invalid format: %s\n", err)
+		os.Exit(2)
+	}
+
+	*appConfig = *cfg
+
+	if err := checkChangeTypeNames(rootCmd); err != nil {
+		fmt.Printf("Invalid change types: %s\n", err)
+		os.Exit(2)
+	}
+
+	manipulationCmds := newChangeTypeCmds(ioStreams)
+	for _, cmd := range manipulationCmds {
+		rootCmd.AddCommand(cmd)
+	}
+
+	if err := executeRoot(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
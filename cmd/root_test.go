@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteRootFlushesOnError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "changelog-root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer rootCmd.SetArgs(nil)
+
+	filename := filepath.Join(dir, "CHANGELOG.md")
+	ioutil.WriteFile(filename, []byte("# Changelog\n\n## 1.0.0\n### Added\n- Item\n"), 0644)
+
+	t.Run("lint", func(t *testing.T) {
+		output := filepath.Join(dir, "lint.txt")
+		rootCmd.SetArgs([]string{"lint", "--filename", filename, "--output", output})
+
+		assert.EqualError(t, executeRoot(), "Found 2 problem(s)")
+		content, _ := ioutil.ReadFile(output)
+		assert.Equal(t, "missing Unreleased version\n1.0.0: missing release date\n", string(content))
+	})
+
+	t.Run("workspace-lint", func(t *testing.T) {
+		output := filepath.Join(dir, "workspace.txt")
+		rootCmd.SetArgs([]string{"workspace", "lint", "--pattern", filename, "--output", output})
+
+		assert.EqualError(t, executeRoot(), "1 of 1 package(s) failed")
+		content, _ := ioutil.ReadFile(output)
+		assert.Contains(t, string(content), "1.0.0: missing release date\nerror: found 2 problem(s)\n")
+	})
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func newSearchCmd(iostreams *IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Search the changelog items",
+		Long: `Search the items containing [query], ignoring case, and show them with
+their version, date and change type. Use --regex for a regular expression
+or --fuzzy to tolerate typos. Without a query, the items are selected by
+the filters.
+
+The version range works like in 'serve': --from is exclusive and --to is
+inclusive. --since and --until select the release dates, leaving
+Unreleased out.`,
+		Example: `  changelog search crash --type fixed
+  changelog search --fuzzy "empty flie"
+  changelog search --issue 123 --format json
+  changelog search --author octocat --from 1.0.0 --since 2024-01-01`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			query, err := queryFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			query.Text = strings.Join(args, " ")
+
+			format, _ := cmd.Flags().GetString("format")
+			if format != "text" && format != "json" {
+				return fmt.Errorf("Unknown format '%s'", format)
+			}
+
+			doc, err := readInput(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+
+			matches, err := doc.Search(query)
+			if err != nil {
+				return err
+			}
+
+			if format == "json" {
+				if matches == nil {
+					matches = []chg.Match{}
+				}
+				enc := json.NewEncoder(iostreams.Out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(matches)
+			}
+
+			for _, m := range matches {
+				fmt.Fprintln(iostreams.Out, m)
+			}
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.Bool("regex", false, "Match the query as a regular expression")
+	fs.Bool("fuzzy", false, "Match the words of the query tolerating typos")
+	fs.StringSliceP("type", "t", nil, "Change type of the items (can be repeated)")
+	fs.String("from", "", "Search versions newer than this one")
+	fs.String("to", "", "Search versions up to this one")
+	fs.String("since", "", "Search versions released on or after the date")
+	fs.String("until", "", "Search versions released on or before the date")
+	fs.String("issue", "", "Issue reference, eg. 123 or org/repo#123")
+	fs.String("author", "", "Mentioned author, eg. octocat")
+	fs.String("format", "text", "Output format: text or json")
+
+	return cmd
+}
+
+// queryFromFlags builds the search query from the filter flags
+func queryFromFlags(cmd *cobra.Command) (chg.Query, error) {
+	fs := cmd.Flags()
+	regex, _ := fs.GetBool("regex")
+	fuzzy, _ := fs.GetBool("fuzzy")
+	types, _ := fs.GetStringSlice("type")
+
+	query := chg.Query{Mode: chg.SearchSubstring}
+	query.From, _ = fs.GetString("from")
+	query.To, _ = fs.GetString("to")
+	query.Issue, _ = fs.GetString("issue")
+	query.Author, _ = fs.GetString("author")
+
+	switch {
+	case regex && fuzzy:
+		return query, fmt.Errorf("Use either --regex or --fuzzy")
+	case regex:
+		query.Mode = chg.SearchRegex
+	case fuzzy:
+		query.Mode = chg.SearchFuzzy
+	}
+
+	for _, name := range types {
+		ct := chg.ChangeTypeFromString(name)
+		if ct == chg.Unknown {
+			return query, fmt.Errorf("Unknown change type: '%s'", name)
+		}
+		query.Types = append(query.Types, ct)
+	}
+
+	var err error
+	if query.Since, err = dateFlag(fs, "since"); err != nil {
+		return query, err
+	}
+	if query.Until, err = dateFlag(fs, "until"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// dateFlag parses the date informed in the flag, returning the zero time
+// when it's empty
+func dateFlag(fs *pflag.FlagSet, flag string) (time.Time, error) {
+	value, _ := fs.GetString(flag)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := chg.ParseDate(value)
+	if err != nil {
+		return t, fmt.Errorf("Invalid --%s date '%s', use a date like 2024-01-31", flag, value)
+	}
+	return t, nil
+}
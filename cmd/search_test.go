@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func executeSearchCmd(t *testing.T, args ...string) (string, error) {
+	changelog, err := ioutil.ReadFile("testdata/search-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	cmd := newSearchCmd(iostreams)
+	cmd.SetArgs(args)
+	_, err = cmd.ExecuteC()
+
+	return out.String(), err
+}
+
+func TestSearchCmd(t *testing.T) {
+	expected := `1.1.0 (2020-02-01): Fixed: Crash on empty files (#12)
+1.0.0 (2020-01-01): Fixed: Crash on startup by @octo
+`
+
+	out, err := executeSearchCmd(t, "CRASH")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out)
+}
+
+func TestSearchCmdFilters(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"json", "--type", "fixed"}, "1.1.0 (2020-02-01): Fixed: Wrong dates in JSON output\n"},
+		{[]string{"--fuzzy", "crahs empty"}, "1.1.0 (2020-02-01): Fixed: Crash on empty files (#12)\n"},
+		{[]string{"--regex", "^Crash.*octo$"}, "1.0.0 (2020-01-01): Fixed: Crash on startup by @octo\n"},
+		{[]string{"--author", "octo"}, "1.0.0 (2020-01-01): Fixed: Crash on startup by @octo\n"},
+		{[]string{"--from", "1.1.0"}, "Unreleased: Added: Search command by @octocat\n"},
+		{[]string{"--issue", "#12"}, "1.1.0 (2020-02-01): Fixed: Crash on empty files (#12)\n"},
+		{[]string{"output", "--since", "2020-01-15"}, "1.1.0 (2020-02-01): Fixed: Wrong dates in JSON output\n"},
+		{
+			[]string{"output", "--until", "January 1, 2020", "--to", "1.0.0"},
+			"1.0.0 (2020-01-01): Added: JSON output ([#123](https://github.com/rcmachado/changelog/pull/123))\n",
+		},
+	}
+
+	for _, test := range tests {
+		out, err := executeSearchCmd(t, test.args...)
+
+		assert.Nil(t, err, test.args)
+		assert.Equal(t, test.expected, out, test.args)
+	}
+}
+
+func TestSearchCmdJSON(t *testing.T) {
+	expected := `[
+  {
+    "version": "1.1.0",
+    "date": "2020-02-01",
+    "type": "Fixed",
+    "item": "Crash on empty files (#12)"
+  }
+]
+`
+
+	out, err := executeSearchCmd(t, "--issue", "12", "--format", "json")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out)
+
+	out, err = executeSearchCmd(t, "nothing", "--format", "json")
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", out)
+}
+
+func TestSearchCmdErrors(t *testing.T) {
+	tests := map[string][]string{
+		"Use either --regex or --fuzzy":                                                {"--regex", "--fuzzy", "x"},
+		"Unknown change type: 'improved'":                                              {"--type", "improved"},
+		"Invalid --since date 'someday', use a date like 2024-01-31":                   {"--since", "someday"},
+		"Unknown version: '2.0.0'":                                                     {"--to", "2.0.0"},
+		"Unknown format 'xml'":                                                         {"--format", "xml"},
+		"Invalid regular expression '(': error parsing regexp: missing closing ): `(`": {"--regex", "("},
+	}
+
+	for expected, args := range tests {
+		_, err := executeSearchCmd(t, args...)
+
+		assert.EqualError(t, err, expected, args)
+	}
+}
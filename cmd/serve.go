@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rcmachado/changelog/api"
+	"github.com/rcmachado/changelog/config"
+	"github.com/spf13/cobra"
+)
+
+// listenFunc serves the handler on the address until it fails
+type listenFunc func(addr string, handler http.Handler) error
+
+func newServeCmd(cfg *config.Config, listen listenFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve [path]",
+		Short: "Serve the changelog over HTTP",
+		Long: `Serve a changelog file, or the changelogs of a directory, with a REST API.
+
+Routes for a file:
+
+  GET /versions               All versions
+  GET /versions?from=X&to=Y   Versions newer than X up to Y ("latest" is accepted)
+  GET /versions/latest        Newest released version
+  GET /versions/{name}        A version
+
+For a directory, GET /changelogs lists the CHANGELOG.md files found in it
+and its subdirectories, served under /changelogs/{name}/versions.
+
+Responses are JSON, markdown or HTML according to the Accept header. The
+files are read again when they change.`,
+		Example: `  changelog serve --addr :8080
+  curl -H "Accept: text/markdown" localhost:8080/versions?from=1.0.0`,
+		Args:        cobra.MaximumNArgs(1),
+		Annotations: map[string]string{annotationNoInput: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			fs := cmd.Flags()
+			addr, _ := fs.GetString("addr")
+			if addr == "" {
+				addr = cfg.Serve.Address
+			}
+
+			path := cfg.Serve.Path
+			if len(args) > 0 {
+				path = args[0]
+			}
+			if path == "" {
+				path, _ = fs.GetString("filename")
+			}
+			if path == "" || path == "-" {
+				return fmt.Errorf("Inform the changelog file or directory to serve")
+			}
+
+			handler, err := api.NewHandler(path)
+			if err != nil {
+				return fmt.Errorf("Failed to serve '%s': %s", path, err)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Serving %s on http://%s\n", path, addr)
+			return listen(addr, handler)
+		},
+	}
+
+	cmd.Flags().String("addr", "", "Address to listen on (default from configuration, \"localhost:8080\")")
+
+	return cmd
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeCmd(t *testing.T) {
+	var (
+		addr    string
+		handler http.Handler
+	)
+	listen := func(a string, h http.Handler) error {
+		addr, handler = a, h
+		return nil
+	}
+
+	stderr := new(bytes.Buffer)
+	cmd := newServeCmd(config.Default(), listen)
+	cmd.SetArgs([]string{"testdata/show-changelog.md"})
+	cmd.SetErr(stderr)
+	_, err := cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost:8080", addr)
+	assert.Equal(t, "Serving testdata/show-changelog.md on http://localhost:8080\n", stderr.String())
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/versions/latest", nil)
+	r.Header.Set("Accept", "text/markdown")
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "## [1.0.0] - 2020-01-08\n")
+}
+
+func TestServeCmdConfig(t *testing.T) {
+	var addr string
+	listen := func(a string, h http.Handler) error {
+		addr = a
+		return nil
+	}
+
+	cfg := config.Default()
+	cfg.Serve.Path = "testdata"
+
+	cmd := newServeCmd(cfg, listen)
+	cmd.SetArgs([]string{"--addr", ":9000"})
+	cmd.SetErr(new(bytes.Buffer))
+	_, err := cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, ":9000", addr)
+}
+
+func TestServeCmdMissingPath(t *testing.T) {
+	cmd := newServeCmd(config.Default(), nil)
+	cmd.SetArgs([]string{"testdata/missing.md"})
+	cmd.SetErr(new(bytes.Buffer))
+	_, err := cmd.ExecuteC()
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Failed to serve 'testdata/missing.md'")
+}
@@ -2,29 +2,117 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
-	"github.com/rcmachado/changelog/parser"
+	"github.com/rcmachado/changelog/changelog"
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/render"
 	"github.com/spf13/cobra"
 )
 
 func newShowCmd(iostreams *IOStreams) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "show [version]",
 		Short: "Show changelog for [version]",
-		Long:  `Show changelog section and entries for version [version]`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Show changelog section and entries for version [version], or the whole
+changelog when no version is informed.
+
+Available formats: ` + strings.Join(render.Formats(), ", ") + `.
+
+The text format strips the markup and wraps the lines to the terminal
+width (--width, or $COLUMNS). The ansi format also highlights the
+versions and change types when the output is a terminal and NO_COLOR
+isn't set.
+
+With --since-stable, it shows the versions after the latest stable
+release, eg. Unreleased and the pre-releases of the next version.`,
+		Example: `  changelog show 1.2.0
+  changelog show --since-stable
+  changelog show --format rst -o CHANGES.rst
+  changelog show --format ansi Unreleased`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			version := args[0]
-			changelog := parser.Parse(iostreams.In)
+			format, _ := cmd.Flags().GetString("format")
+			if _, err := render.New(format); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			width, _ := cmd.Flags().GetInt("width")
+			opts := textOptions(format, width, iostreams)
+
+			sinceStable, _ := cmd.Flags().GetBool("since-stable")
+			if sinceStable && len(args) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("--since-stable can't be used with a version")
+			}
+
+			doc, err := readInput(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+
+			if sinceStable {
+				var from string
+				if stable := doc.LatestStable(); stable != nil {
+					from = stable.Name
+				}
+				versions, _ := doc.Between(from, "")
+				names := []string{}
+				for _, v := range versions {
+					names = append(names, v.Name)
+				}
+				return doc.Render(cmd.Context(), iostreams.Out, append(opts, changelog.WithVersions(names...))...)
+			}
 
-			v := changelog.Version(version)
+			if len(args) == 0 {
+				return doc.Render(cmd.Context(), iostreams.Out, opts...)
+			}
+
+			version := args[0]
+			v := doc.Version(version)
 			if v == nil {
 				cmd.SilenceUsage = true
 				return fmt.Errorf("Unknown version: '%s'\n", version)
 			}
 
+			if format != "markdown" {
+				return doc.RenderVersion(cmd.Context(), iostreams.Out, version, opts...)
+			}
+
 			v.RenderChanges(iostreams.Out)
+
+			// Keep the reference-style links of the items working
+			if links := doc.UsedLinks([]*chg.Version{v}); len(links) > 0 {
+				fmt.Fprintf(iostreams.Out, "\n")
+				for _, l := range links {
+					l.Render(iostreams.Out)
+				}
+			}
 			return nil
 		},
 	}
+
+	fs := cmd.Flags()
+	fs.String("format", "markdown", "Output format: "+strings.Join(render.Formats(), ", "))
+	fs.Int("width", 0, "Columns of the text formats, $COLUMNS or 80 by default")
+	fs.Bool("since-stable", false, "Show the versions after the latest stable release")
+
+	return cmd
+}
+
+// textOptions configures the text formats for the output: colors are
+// only used in terminals when NO_COLOR isn't set, and the width
+// defaults to $COLUMNS
+func textOptions(format string, width int, iostreams *IOStreams) []changelog.Option {
+	if width <= 0 {
+		width, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+	}
+	color := iostreams.OutTerminal && os.Getenv("NO_COLOR") == ""
+	return []changelog.Option{
+		changelog.WithFormat(format),
+		changelog.WithWidth(width),
+		changelog.WithColor(color),
+	}
 }
@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"io/ioutil"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,3 +57,199 @@ func TestShowCmdUnknownVersion(t *testing.T) {
 
 	assert.NotNil(t, err)
 }
+
+func TestShowCmdReferenceLinks(t *testing.T) {
+	changelog := `# Changelog
+
+## [1.0.0] - 2020-01-02
+### Fixed
+- Crash on empty files [#12]
+
+[1.0.0]: https://example.com/v1.0.0
+[#12]: https://example.com/issues/12
+[#13]: https://example.com/issues/13
+`
+
+	expected := `### Fixed
+- Crash on empty files [#12]
+
+[#12]: https://example.com/issues/12
+`
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  strings.NewReader(changelog),
+		Out: out,
+	}
+
+	cmd := newShowCmd(iostreams)
+	cmd.SetArgs([]string{"1.0.0"})
+	_, err := cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out.String())
+}
+
+func TestShowCmdFormat(t *testing.T) {
+	changelog, err := ioutil.ReadFile("testdata/show-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `[#version-1.0.0]
+== link:https://github.com/rcmachado/changelog/compare/ae761ff...1.0.0[1.0.0] - 2020-01-08
+
+=== Added
+
+* Item 1
+* Item 2
+
+=== Changed
+
+* Item 3
+`
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	cmd := newShowCmd(iostreams)
+	cmd.SetArgs([]string{"1.0.0", "--format", "asciidoc"})
+	_, err = cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out.String())
+}
+
+func TestShowCmdWholeChangelog(t *testing.T) {
+	changelog, err := ioutil.ReadFile("testdata/show-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	cmd := newShowCmd(iostreams)
+	cmd.SetArgs([]string{"--format", "rst"})
+	_, err = cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(out.String(), "Changelog\n=========\n"))
+	assert.Contains(t, out.String(), ".. _version-unreleased:\n\n`Unreleased <https://github.com/rcmachado/changelog/compare/1.0.0...HEAD>`__\n")
+	assert.Contains(t, out.String(), ".. _version-1.0.0:\n")
+}
+
+func TestShowCmdUnknownFormat(t *testing.T) {
+	iostreams := &IOStreams{
+		In:  strings.NewReader(""),
+		Out: new(bytes.Buffer),
+	}
+
+	cmd := newShowCmd(iostreams)
+	cmd.SetArgs([]string{"1.0.0", "--format", "pdf"})
+	_, err := cmd.ExecuteC()
+
+	assert.EqualError(t, err, "Unknown format: 'pdf'")
+}
+
+func TestShowCmdColor(t *testing.T) {
+	changelog := `# Changelog
+
+## 1.0.0 - 2020-01-08
+### Added
+- Item 1
+`
+
+	tests := []struct {
+		terminal bool
+		noColor  string
+		expected string
+	}{
+		{true, "", "\x1b[1m1.0.0 - 2020-01-08\x1b[0m\n\n\x1b[32mAdded:\x1b[0m\n  - Item 1\n"},
+		{true, "1", "1.0.0 - 2020-01-08\n------------------\n\nAdded:\n  - Item 1\n"},
+		{false, "", "1.0.0 - 2020-01-08\n------------------\n\nAdded:\n  - Item 1\n"},
+	}
+
+	defer os.Unsetenv("NO_COLOR")
+	for _, test := range tests {
+		os.Setenv("NO_COLOR", test.noColor)
+
+		out := new(bytes.Buffer)
+		iostreams := &IOStreams{
+			In:          strings.NewReader(changelog),
+			Out:         out,
+			OutTerminal: test.terminal,
+		}
+
+		cmd := newShowCmd(iostreams)
+		cmd.SetArgs([]string{"1.0.0", "--format", "ansi"})
+		_, err := cmd.ExecuteC()
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, out.String())
+	}
+}
+
+func TestShowCmdTextWidth(t *testing.T) {
+	changelog := `# Changelog
+
+## 1.0.0 - 2020-01-08
+### Added
+- Support the ` + "`text`" + ` format in the show command
+`
+
+	expected := `1.0.0 - 2020-01-08
+------------------
+
+Added:
+  - Support the text format in
+    the show command
+`
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  strings.NewReader(changelog),
+		Out: out,
+	}
+
+	cmd := newShowCmd(iostreams)
+	cmd.SetArgs([]string{"1.0.0", "--format", "text", "--width", "30"})
+	_, err := cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out.String())
+}
+
+func TestShowCmdSinceStable(t *testing.T) {
+	changelog, err := ioutil.ReadFile("testdata/prerelease-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	cmd := newShowCmd(iostreams)
+	cmd.SetArgs([]string{"--since-stable", "--format", "json"})
+	_, err = cmd.ExecuteC()
+
+	assert.Nil(t, err)
+	var versions []struct{ Name string }
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &versions))
+	assert.Equal(t, []struct{ Name string }{{"Unreleased"}, {"2.0.0-rc.2"}, {"2.0.0-rc.1"}}, versions)
+
+	cmd = newShowCmd(&IOStreams{In: bytes.NewBuffer(changelog), Out: new(bytes.Buffer)})
+	cmd.SetArgs([]string{"--since-stable", "1.0.0"})
+	_, err = cmd.ExecuteC()
+
+	assert.EqualError(t, err, "--since-stable can't be used with a version")
+}
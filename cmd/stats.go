@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/spf13/cobra"
+)
+
+func newStatsCmd(iostreams *IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show release and change statistics",
+		Long: `Show the release cadence and the changes of the changelog: releases per
+month and quarter, average days between releases, yanked releases, the
+items per change type and version, and the longest time changes waited
+in Unreleased.
+
+Releases without a valid date are counted, but left out of the cadence.
+The csv format has a row per version, with the days since the previous
+release and the items per change type.`,
+		Example: `  changelog stats
+  changelog stats --format csv -o stats.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			format, _ := cmd.Flags().GetString("format")
+			if format != "text" && format != "json" && format != "csv" {
+				return fmt.Errorf("Unknown format '%s'", format)
+			}
+
+			doc, err := readInput(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+
+			stats := doc.Stats(time.Now())
+
+			switch format {
+			case "json":
+				enc := json.NewEncoder(iostreams.Out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(stats)
+			case "csv":
+				return writeStatsCSV(iostreams.Out, stats)
+			default:
+				writeStatsText(iostreams.Out, stats)
+				return nil
+			}
+		},
+	}
+
+	cmd.Flags().String("format", "text", "Output format: text, json or csv")
+
+	return cmd
+}
+
+// writeStatsText writes the statistics as aligned tables
+func writeStatsText(out io.Writer, stats chg.Stats) {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Releases:\t%d\n", stats.Releases)
+	fmt.Fprintf(w, "Yanked:\t%d (%s)\n", stats.Yanked, percent(stats.YankedRate))
+	fmt.Fprintf(w, "Average days between releases:\t%.1f\n", stats.AverageDays)
+	if p := stats.LongestUnreleased; p != nil {
+		fmt.Fprintf(w, "Longest Unreleased period:\t%s\n", p)
+	}
+	w.Flush()
+
+	if len(stats.Quarters) > 0 {
+		fmt.Fprintf(out, "\nReleases per quarter:\n")
+		for _, p := range stats.Quarters {
+			fmt.Fprintf(w, "  %s\t%d\n", p.Period, p.Releases)
+		}
+		w.Flush()
+
+		fmt.Fprintf(out, "\nReleases per month:\n")
+		for _, p := range stats.Months {
+			fmt.Fprintf(w, "  %s\t%d\n", p.Period, p.Releases)
+		}
+		w.Flush()
+	}
+
+	fmt.Fprintf(out, "\nItems per change type:\n")
+	for _, ts := range stats.Types {
+		fmt.Fprintf(w, "  %s\t%d\t%s\n", ts.Type, ts.Items, percent(ts.Share))
+	}
+	fmt.Fprintf(w, "  Total\t%d\n", stats.Items)
+	w.Flush()
+
+	fmt.Fprintf(out, "\nItems per version:\n")
+	fmt.Fprintf(w, "  Version\tDate")
+	for _, ts := range stats.Types {
+		fmt.Fprintf(w, "\t%s", ts.Type)
+	}
+	fmt.Fprintf(w, "\tTotal\n")
+	for _, vs := range stats.Versions {
+		date := vs.Date
+		if vs.Yanked {
+			date += " [YANKED]"
+		}
+		fmt.Fprintf(w, "  %s\t%s", vs.Version, date)
+		for _, ts := range stats.Types {
+			fmt.Fprintf(w, "\t%d", vs.Types[ts.Type])
+		}
+		fmt.Fprintf(w, "\t%d\n", vs.Items)
+	}
+	w.Flush()
+}
+
+// writeStatsCSV writes a row per version
+func writeStatsCSV(out io.Writer, stats chg.Stats) error {
+	w := csv.NewWriter(out)
+
+	header := []string{"version", "date", "yanked", "days_since_previous"}
+	for _, ts := range stats.Types {
+		header = append(header, ts.Type)
+	}
+	w.Write(append(header, "total"))
+
+	for _, vs := range stats.Versions {
+		days := ""
+		if vs.DaysSincePrevious != nil {
+			days = strconv.Itoa(*vs.DaysSincePrevious)
+		}
+		row := []string{vs.Version, vs.Date, strconv.FormatBool(vs.Yanked), days}
+		for _, ts := range stats.Types {
+			row = append(row, strconv.Itoa(vs.Types[ts.Type]))
+		}
+		w.Write(append(row, strconv.Itoa(vs.Items)))
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// percent formats a share from 0 to 1
+func percent(share float64) string {
+	return strconv.FormatFloat(share*100, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/stretchr/testify/assert"
+)
+
+func executeStatsCmd(t *testing.T, args ...string) (string, error) {
+	changelog, err := ioutil.ReadFile("testdata/search-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	cmd := newStatsCmd(iostreams)
+	cmd.SetArgs(args)
+	_, err = cmd.ExecuteC()
+
+	return out.String(), err
+}
+
+func TestStatsCmd(t *testing.T) {
+	out, err := executeStatsCmd(t)
+
+	assert.Nil(t, err)
+	assert.Contains(t, out, "Releases:                       2\n")
+	assert.Contains(t, out, "Average days between releases:  31.0\n")
+	assert.Contains(t, out, "Releases per quarter:\n  2020-Q1  2\n")
+	assert.Contains(t, out, "Releases per month:\n  2020-01  1\n  2020-02  1\n")
+	assert.Contains(t, out, "  Fixed       3  60.0%\n")
+	assert.Contains(t, out, "  1.1.0       2020-02-01  0      0        0           2      0        0         2\n")
+}
+
+func TestStatsCmdJSON(t *testing.T) {
+	out, err := executeStatsCmd(t, "--format", "json")
+	assert.Nil(t, err)
+
+	var stats chg.Stats
+	assert.Nil(t, json.Unmarshal([]byte(out), &stats))
+	assert.Equal(t, 2, stats.Releases)
+	assert.Equal(t, 5, stats.Items)
+	assert.Equal(t, "Unreleased", stats.LongestUnreleased.Version)
+}
+
+func TestStatsCmdCSV(t *testing.T) {
+	expected := `version,date,yanked,days_since_previous,Added,Changed,Deprecated,Fixed,Removed,Security,total
+Unreleased,,false,,1,0,0,0,0,0,1
+1.1.0,2020-02-01,false,31,0,0,0,2,0,0,2
+1.0.0,2020-01-01,false,,1,0,0,1,0,0,2
+`
+
+	out, err := executeStatsCmd(t, "--format", "csv")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out)
+}
+
+func TestStatsCmdUnknownFormat(t *testing.T) {
+	_, err := executeStatsCmd(t, "--format", "xml")
+
+	assert.EqualError(t, err, "Unknown format 'xml'")
+}
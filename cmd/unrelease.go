@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/spf13/cobra"
+)
+
+func newUnreleaseCmd(iostreams *IOStreams, cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unrelease [version]",
+		Short: "Revert the latest release into Unreleased",
+		Long: `Revert what 'release' did to the latest version, eg. when it was released
+with the wrong name: its items are merged back into Unreleased, with the
+ones added after the release, and the Unreleased compare link is
+restored.
+
+The version is optional and, when informed, has to be the latest
+release. The lockfile (see 'release') is updated accordingly.`,
+		Example: `  changelog unrelease 1.3.0 -o CHANGELOG.md
+  changelog unrelease -o CHANGELOG.md && changelog release 1.2.1 -o CHANGELOG.md`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var version string
+			if len(args) > 0 {
+				version = args[0]
+			}
+
+			doc, err := readInputToRewrite(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+			edit, err := startLockedEdit(cmd, lockfilePath(cmd, cfg), doc.Changelog)
+			if err != nil {
+				return err
+			}
+
+			if _, err := doc.Unrelease(version); err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("Failed to unrelease: %s", err)
+			}
+
+			if err := doc.Render(cmd.Context(), iostreams.Out); err != nil {
+				return err
+			}
+
+			return edit.finish(cmd, doc.Changelog)
+		},
+	}
+
+	cmd.Flags().String("lockfile", "", "Lockfile to update with the released versions hashes")
+
+	return cmd
+}
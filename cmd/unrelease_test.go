@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/lockfile"
+	"github.com/rcmachado/changelog/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func executeUnreleaseCmd(t *testing.T, cfg *config.Config, args ...string) (string, error) {
+	changelog, err := ioutil.ReadFile("testdata/show-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBuffer(changelog),
+		Out: out,
+	}
+
+	cmd := newUnreleaseCmd(iostreams, cfg)
+	cmd.SetArgs(args)
+	_, err = cmd.ExecuteC()
+
+	return out.String(), err
+}
+
+func TestUnreleaseCmd(t *testing.T) {
+	expected := `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](http://keepachangelog.com/en/1.0.0/)
+and this project adheres to [Semantic Versioning](http://semver.org/spec/v2.0.0.html).
+
+## [Unreleased]
+### Added
+- Item 1
+- Item 2
+
+### Changed
+- Item 3
+
+### Removed
+- Item 4
+
+[Unreleased]: https://github.com/rcmachado/changelog/compare/ae761ff...HEAD
+`
+
+	for _, args := range [][]string{{}, {"1.0.0"}} {
+		out, err := executeUnreleaseCmd(t, config.Default(), args...)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, out)
+	}
+}
+
+func TestUnreleaseCmdError(t *testing.T) {
+	_, err := executeUnreleaseCmd(t, config.Default(), "0.9.0")
+
+	assert.EqualError(t, err, "Failed to unrelease: unknown version: '0.9.0'")
+}
+
+func TestUnreleaseCmdLockfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "changelog-unrelease")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	changelog, _ := ioutil.ReadFile("testdata/show-changelog.md")
+	lockPath := filepath.Join(dir, ".changelog.lock")
+	lockfile.New(parser.ParseBytes(changelog)).Save(lockPath)
+
+	cfg := config.Default()
+	cfg.Guard.Lockfile = lockPath
+
+	_, err = executeUnreleaseCmd(t, cfg)
+	assert.Nil(t, err)
+
+	lock, err := lockfile.Load(lockPath)
+	assert.Nil(t, err)
+	assert.Empty(t, lock.Versions)
+}
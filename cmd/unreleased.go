@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/spf13/cobra"
+)
+
+// unreleasedSummary is the JSON output of the unreleased command
+type unreleasedSummary struct {
+	Items            int                 `json:"items"`
+	Types            []unreleasedChanges `json:"types"`
+	SuggestedVersion string              `json:"suggestedVersion,omitempty"`
+}
+
+type unreleasedChanges struct {
+	Type  string   `json:"type"`
+	Items []string `json:"items"`
+}
+
+func newUnreleasedCmd(iostreams *IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unreleased",
+		Short: "Show the pending changes",
+		Long: `Show the items of Unreleased, their number per change type and the
+suggested next version: major when something was removed or an item
+starts with "BREAKING", minor for additions, changes and deprecations
+and patch otherwise.
+
+With --assert-empty or --assert-nonempty, it exits with an error when
+Unreleased has items or has none, eg. to check a hotfix branch or a
+release job.`,
+		Example: `  changelog unreleased
+  changelog unreleased --assert-nonempty --quiet`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			fs := cmd.Flags()
+			assertEmpty, _ := fs.GetBool("assert-empty")
+			assertNonEmpty, _ := fs.GetBool("assert-nonempty")
+			quiet, _ := fs.GetBool("quiet")
+			format, _ := fs.GetString("format")
+
+			if assertEmpty && assertNonEmpty {
+				return fmt.Errorf("Use either --assert-empty or --assert-nonempty")
+			}
+			if format != "text" && format != "json" {
+				return fmt.Errorf("Unknown format '%s'", format)
+			}
+
+			doc, err := readInput(cmd, iostreams)
+			if err != nil {
+				return err
+			}
+
+			summary := summarizeUnreleased(doc.Changelog)
+
+			if !quiet {
+				if format == "json" {
+					enc := json.NewEncoder(iostreams.Out)
+					enc.SetIndent("", "  ")
+					if err := enc.Encode(summary); err != nil {
+						return err
+					}
+				} else {
+					writeUnreleasedText(iostreams.Out, doc.Changelog, summary)
+				}
+			}
+
+			if assertEmpty && summary.Items > 0 {
+				return fmt.Errorf("Unreleased has %d item(s)", summary.Items)
+			}
+			if assertNonEmpty && summary.Items == 0 {
+				return fmt.Errorf("Unreleased has no items")
+			}
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.Bool("assert-empty", false, "Exit with an error when Unreleased has items")
+	fs.Bool("assert-nonempty", false, "Exit with an error when Unreleased has no items")
+	fs.BoolP("quiet", "q", false, "Don't show the changes, only check the assertion")
+	fs.String("format", "text", "Output format: text or json")
+
+	return cmd
+}
+
+// summarizeUnreleased lists the items of Unreleased per change type
+func summarizeUnreleased(changelog *chg.Changelog) unreleasedSummary {
+	summary := unreleasedSummary{Types: []unreleasedChanges{}}
+
+	v := changelog.Version("Unreleased")
+	if v == nil {
+		return summary
+	}
+
+	for _, ct := range chg.ChangeTypes() {
+		cl := v.Change(ct)
+		if cl == nil || len(cl.Items) == 0 {
+			continue
+		}
+		changes := unreleasedChanges{Type: ct.String()}
+		for _, item := range cl.Items {
+			changes.Items = append(changes.Items, item.Text())
+		}
+		summary.Types = append(summary.Types, changes)
+		summary.Items += len(cl.Items)
+	}
+
+	summary.SuggestedVersion = changelog.NextVersion()
+	return summary
+}
+
+// writeUnreleasedText writes the counts followed by the changes in
+// markdown
+func writeUnreleasedText(out io.Writer, changelog *chg.Changelog, summary unreleasedSummary) {
+	if summary.Items == 0 {
+		fmt.Fprintln(out, "Unreleased has no items")
+		return
+	}
+
+	fmt.Fprintf(out, "Unreleased has %d item(s)", summary.Items)
+	if summary.SuggestedVersion != "" {
+		fmt.Fprintf(out, ", suggested version: %s", summary.SuggestedVersion)
+	}
+	fmt.Fprintf(out, "\n\n")
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, changes := range summary.Types {
+		fmt.Fprintf(w, "  %s\t%d\n", changes.Type, len(changes.Items))
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\n")
+	changelog.Version("Unreleased").RenderChanges(out)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func executeUnreleasedCmd(t *testing.T, changelog string, args ...string) (string, error) {
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{
+		In:  bytes.NewBufferString(changelog),
+		Out: out,
+	}
+
+	cmd := newUnreleasedCmd(iostreams)
+	cmd.SetArgs(args)
+	_, err := cmd.ExecuteC()
+
+	return out.String(), err
+}
+
+func readTestdata(t *testing.T, filename string) string {
+	content, err := ioutil.ReadFile("testdata/" + filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestUnreleasedCmd(t *testing.T) {
+	expected := `Unreleased has 1 item(s), suggested version: 2.0.0
+
+  Removed  1
+
+### Removed
+- Item 4
+`
+
+	out, err := executeUnreleasedCmd(t, readTestdata(t, "show-changelog.md"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out)
+}
+
+func TestUnreleasedCmdJSON(t *testing.T) {
+	expected := `{
+  "items": 1,
+  "types": [
+    {
+      "type": "Added",
+      "items": [
+        "Search command by @octocat"
+      ]
+    }
+  ],
+  "suggestedVersion": "1.2.0"
+}
+`
+
+	out, err := executeUnreleasedCmd(t, readTestdata(t, "search-changelog.md"), "--format", "json")
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, out)
+}
+
+func TestUnreleasedCmdEmpty(t *testing.T) {
+	changelog := "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2020-01-01\n### Added\n- Item\n"
+
+	out, err := executeUnreleasedCmd(t, changelog)
+	assert.Nil(t, err)
+	assert.Equal(t, "Unreleased has no items\n", out)
+
+	out, err = executeUnreleasedCmd(t, changelog, "--format", "json")
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n  \"items\": 0,\n  \"types\": []\n}\n", out)
+}
+
+func TestUnreleasedCmdAssert(t *testing.T) {
+	empty := "# Changelog\n\n## [Unreleased]\n"
+	pending := readTestdata(t, "show-changelog.md")
+
+	_, err := executeUnreleasedCmd(t, empty, "--assert-empty")
+	assert.Nil(t, err)
+
+	out, err := executeUnreleasedCmd(t, pending, "--assert-empty", "--quiet")
+	assert.EqualError(t, err, "Unreleased has 1 item(s)")
+	assert.Empty(t, out)
+
+	_, err = executeUnreleasedCmd(t, pending, "--assert-nonempty")
+	assert.Nil(t, err)
+
+	_, err = executeUnreleasedCmd(t, empty, "--assert-nonempty", "-q")
+	assert.EqualError(t, err, "Unreleased has no items")
+
+	_, err = executeUnreleasedCmd(t, empty, "--assert-empty", "--assert-nonempty")
+	assert.EqualError(t, err, "Use either --assert-empty or --assert-nonempty")
+}
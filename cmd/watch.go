@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/render"
+	"github.com/rcmachado/changelog/watch"
+	"github.com/spf13/cobra"
+)
+
+func newWatchCmd(iostreams *IOStreams, cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Regenerate outputs when the changelog changes",
+		Long: `Watch the changelog and regenerate the configured outputs whenever it
+changes, printing the lint problems found.
+
+Outputs are informed with --write format=path or configured in the 'watch'
+section of the configuration file. Available formats: ` + strings.Join(render.Formats(), ", ") + `.
+
+Changes to the files or directories informed with --path (eg. a directory
+of changelog fragments) also trigger a rebuild.`,
+		Example:     `  changelog watch --write html=docs/changelog.html --write json=docs/changelog.json`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{annotationNoInput: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			fs := cmd.Flags()
+			filename, _ := fs.GetString("filename")
+			if filename == "" || filename == "-" {
+				return fmt.Errorf("watch requires a changelog file")
+			}
+			writes, _ := fs.GetStringSlice("write")
+			paths, _ := fs.GetStringSlice("path")
+			debounce, _ := fs.GetDuration("debounce")
+
+			outputs, err := watchOutputs(cfg, writes)
+			if err != nil {
+				return err
+			}
+
+			w := &watch.Watcher{
+				Filename: filename,
+				Paths:    append(append([]string{}, cfg.Watch.Paths...), paths...),
+				Outputs:  outputs,
+				Debounce: debounce,
+				Log:      iostreams.Out,
+			}
+
+			stop := make(chan struct{})
+			interrupt := make(chan os.Signal, 1)
+			signal.Notify(interrupt, os.Interrupt)
+			go func() {
+				<-interrupt
+				close(stop)
+			}()
+
+			return w.Run(stop)
+		},
+	}
+
+	fs := cmd.Flags()
+	fs.StringSlice("write", nil, "Output to regenerate as format=path (can be repeated)")
+	fs.StringSlice("path", nil, "Other file or directory to watch (can be repeated)")
+	fs.Duration("debounce", watch.DefaultDebounce, "Time to wait for more changes before regenerating")
+
+	return cmd
+}
+
+// watchOutputs merges the configured outputs with the ones informed as
+// format=path, validating the formats
+func watchOutputs(cfg *config.Config, writes []string) ([]watch.Output, error) {
+	var outputs []watch.Output
+	for _, out := range cfg.Watch.Outputs {
+		outputs = append(outputs, watch.Output{Format: out.Format, Path: out.Path})
+	}
+
+	for _, w := range writes {
+		parts := strings.SplitN(w, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("Invalid output '%s', expected format=path", w)
+		}
+		outputs = append(outputs, watch.Output{Format: parts[0], Path: parts[1]})
+	}
+
+	for _, out := range outputs {
+		if _, err := render.New(out.Format); err != nil {
+			return nil, err
+		}
+	}
+	return outputs, nil
+}
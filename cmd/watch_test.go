@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/watch"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchOutputs(t *testing.T) {
+	cfg := config.Default()
+	cfg.Watch.Outputs = []config.WatchOutput{{Format: "html", Path: "docs/changelog.html"}}
+
+	outputs, err := watchOutputs(cfg, []string{"json=changelog.json"})
+
+	expected := []watch.Output{
+		{Format: "html", Path: "docs/changelog.html"},
+		{Format: "json", Path: "changelog.json"},
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, expected, outputs)
+}
+
+func TestWatchOutputsInvalid(t *testing.T) {
+	_, err := watchOutputs(config.Default(), []string{"changelog.json"})
+	assert.EqualError(t, err, "Invalid output 'changelog.json', expected format=path")
+
+	_, err = watchOutputs(config.Default(), []string{"pdf=changelog.pdf"})
+	assert.EqualError(t, err, "Unknown format: 'pdf'")
+}
+
+func TestWatchCmdStdin(t *testing.T) {
+	iostreams := &IOStreams{Out: new(bytes.Buffer)}
+
+	cmd := newWatchCmd(iostreams, config.Default())
+	cmd.Flags().String("filename", "-", "")
+	_, err := cmd.ExecuteC()
+
+	assert.EqualError(t, err, "watch requires a changelog file")
+}
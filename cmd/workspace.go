@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/rcmachado/changelog/changelog"
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/workspace"
+	"github.com/spf13/cobra"
+)
+
+func newWorkspaceCmd(iostreams *IOStreams, cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Run commands across all changelogs of a monorepo",
+		Long: `Run commands across all changelogs of a monorepo.
+
+Changelogs are the ones listed under 'workspace' in the configuration
+file or the ones matching --pattern. They are processed concurrently
+and the results are reported per package, in order.`,
+		Annotations: map[string]string{annotationNoInput: "true"},
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringSliceP("pattern", "p", nil, "Glob pattern matching changelog files (overrides configuration)")
+	flags.IntP("jobs", "j", runtime.NumCPU(), "Number of changelogs processed concurrently")
+
+	cmd.AddCommand(
+		newWorkspaceLintCmd(iostreams, cfg),
+		newWorkspaceFmtCmd(iostreams, cfg),
+		newWorkspaceShowCmd(iostreams, cfg),
+		newWorkspaceReleaseCmd(iostreams, cfg),
+	)
+
+	return cmd
+}
+
+func newWorkspaceLintCmd(iostreams *IOStreams, cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint",
+		Short: "Check all changelogs for problems",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorkspace(cmd, iostreams, cfg, nil, func(pkg workspace.Package) (string, error) {
+				doc, _, err := readChangelog(cmd.Context(), pkg.Path)
+				if err != nil {
+					return "", err
+				}
+
+				var invalid *changelog.ValidationError
+				if !errors.As(doc.Validate(), &invalid) {
+					return "", nil
+				}
+
+				var out strings.Builder
+				for _, p := range invalid.Problems {
+					fmt.Fprintln(&out, p)
+				}
+				return out.String(), fmt.Errorf("found %d problem(s)", len(invalid.Problems))
+			})
+		},
+	}
+}
+
+func newWorkspaceFmtCmd(iostreams *IOStreams, cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fmt",
+		Short: "Reformat all changelogs in place",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			check, _ := cmd.Flags().GetBool("check")
+
+			return runWorkspace(cmd, iostreams, cfg, nil, func(pkg workspace.Package) (string, error) {
+				doc, original, err := readChangelog(cmd.Context(), pkg.Path)
+				if err != nil {
+					return "", err
+				}
+				if err := doc.Rewritable(); err != nil {
+					return "", err
+				}
+
+				var buf bytes.Buffer
+				doc.Changelog.Render(&buf)
+				if bytes.Equal(original, buf.Bytes()) {
+					return "", nil
+				}
+
+				if check {
+					return "", fmt.Errorf("not formatted")
+				}
+				if err := ioutil.WriteFile(pkg.Path, buf.Bytes(), 0644); err != nil {
+					return "", err
+				}
+				return "reformatted\n", nil
+			})
+		},
+	}
+
+	cmd.Flags().Bool("check", false, "Fail if any changelog is not formatted, without changing it")
+
+	return cmd
+}
+
+func newWorkspaceShowCmd(iostreams *IOStreams, cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [version]",
+		Short: "Show [version] for all changelogs",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version := args[0]
+
+			return runWorkspace(cmd, iostreams, cfg, nil, func(pkg workspace.Package) (string, error) {
+				doc, _, err := readChangelog(cmd.Context(), pkg.Path)
+				if err != nil {
+					return "", err
+				}
+
+				v := doc.Version(version)
+				if v == nil {
+					return "", fmt.Errorf("unknown version: '%s'", version)
+				}
+
+				var buf bytes.Buffer
+				v.RenderChanges(&buf)
+				return buf.String(), nil
+			})
+		},
+	}
+}
+
+func newWorkspaceReleaseCmd(iostreams *IOStreams, cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release [package=version]...",
+		Short: "Release the informed packages",
+		Long: `Change Unreleased to the informed version for each package, in place.
+
+The compare links use the package tag prefix, eg. 'pkg-a@1.2.0'.
+Packages not informed are left untouched.
+
+When a lockfile is configured (see 'release'), each package has its
+own, with the same name in the directory of its changelog.
+
+The release date accepts the same values as in 'release'.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fs := cmd.Flags()
+			releaseDate, _ := fs.GetString("release-date")
+			timezone, _ := fs.GetString("timezone")
+			compareURL, _ := fs.GetString("compare-url")
+			lockPath := lockfilePath(cmd, cfg)
+
+			releaseDate, err := parseReleaseDate(releaseDate, timezone, time.Now())
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			versions := make(map[string]string, len(args))
+			for _, arg := range args {
+				parts := strings.SplitN(arg, "=", 2)
+				if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+					return fmt.Errorf("Invalid argument '%s', expected package=version", arg)
+				}
+				versions[parts[0]] = parts[1]
+			}
+
+			filter := func(pkgs []workspace.Package) ([]workspace.Package, error) {
+				var selected []workspace.Package
+				found := make(map[string]bool)
+				for _, pkg := range pkgs {
+					if _, ok := versions[pkg.Name]; ok {
+						selected = append(selected, pkg)
+						found[pkg.Name] = true
+					}
+				}
+				for name := range versions {
+					if !found[name] {
+						return nil, fmt.Errorf("Unknown package '%s'", name)
+					}
+				}
+				return selected, nil
+			}
+
+			return runWorkspace(cmd, iostreams, cfg, filter, func(pkg workspace.Package) (string, error) {
+				doc, _, err := readChangelog(cmd.Context(), pkg.Path)
+				if err != nil {
+					return "", err
+				}
+				if err := doc.Rewritable(); err != nil {
+					return "", err
+				}
+				edit, err := startLockedEdit(cmd, packageLockfile(pkg, lockPath), doc.Changelog)
+				if err != nil {
+					return "", err
+				}
+
+				version := chg.Version{
+					Name: versions[pkg.Name],
+					Date: releaseDate,
+					Link: compareURL,
+				}
+				if _, err := doc.ReleaseWithTagPrefix(version, pkg.TagPrefix); err != nil {
+					return "", err
+				}
+
+				var buf bytes.Buffer
+				doc.Changelog.Render(&buf)
+				if err := ioutil.WriteFile(pkg.Path, buf.Bytes(), 0644); err != nil {
+					return "", err
+				}
+				if err := edit.finish(cmd, doc.Changelog); err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("released %s%s\n", pkg.TagPrefix, version.Name), nil
+			})
+		},
+	}
+
+	fs := cmd.Flags()
+
+	fs.StringP("release-date", "d", "today", "Release date: 'today', 'yesterday' or a date, eg. 2024-01-31")
+	fs.String("timezone", "Local", "Timezone of 'today' and 'yesterday', eg. UTC or America/Sao_Paulo")
+	fs.StringP("compare-url", "c", "", "Overwrite compare URL for Unreleased section")
+	fs.String("lockfile", "", "Name of the lockfiles to update with the released versions hashes")
+
+	return cmd
+}
+
+// packageLockfile returns the lockfile of the package, named like
+// lockPath in the directory of its changelog. It's empty without lockPath.
+func packageLockfile(pkg workspace.Package, lockPath string) string {
+	if lockPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(pkg.Path), filepath.Base(lockPath))
+}
+
+// workspacePackages returns the packages from --pattern or from the configuration
+func workspacePackages(cmd *cobra.Command, cfg *config.Config) ([]workspace.Package, error) {
+	patterns, _ := cmd.Flags().GetStringSlice("pattern")
+	if len(patterns) > 0 {
+		return workspace.Discover(patterns, cfg.Workspace.TagPrefix)
+	}
+
+	var pkgs []workspace.Package
+	for _, p := range cfg.Workspace.Packages {
+		pkgs = append(pkgs, workspace.Package{
+			Name:      p.Name,
+			Path:      p.Path,
+			TagPrefix: workspace.ExpandTagPrefix(p.TagPrefix, p.Name),
+		})
+	}
+
+	discovered, err := workspace.Discover(cfg.Workspace.Patterns, cfg.Workspace.TagPrefix)
+	if err != nil {
+		return nil, err
+	}
+	pkgs = workspace.Unique(append(pkgs, discovered...))
+
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("No changelogs found, configure the workspace or use --pattern")
+	}
+	return pkgs, nil
+}
+
+func runWorkspace(cmd *cobra.Command, iostreams *IOStreams, cfg *config.Config, filter func([]workspace.Package) ([]workspace.Package, error), action workspace.Action) error {
+	cmd.SilenceUsage = true
+
+	pkgs, err := workspacePackages(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	if filter != nil {
+		if pkgs, err = filter(pkgs); err != nil {
+			return err
+		}
+	}
+
+	jobs, _ := cmd.Flags().GetInt("jobs")
+	results := workspace.Run(pkgs, jobs, action)
+
+	failed := 0
+	for _, r := range results {
+		fmt.Fprintf(iostreams.Out, "==> %s (%s)\n", r.Package.Name, r.Package.Path)
+		fmt.Fprint(iostreams.Out, r.Output)
+		if r.Err != nil {
+			failed++
+			fmt.Fprintf(iostreams.Out, "error: %s\n", r.Err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d package(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// readChangelog parses the changelog file, also returning its content
+func readChangelog(ctx context.Context, filename string) (*changelog.Document, []byte, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := changelog.Read(ctx, bytes.NewReader(content))
+	return doc, content, err
+}
+
+// parseChangelog parses a changelog read from somewhere else than the
+// input, like a file or a git revision
+func parseChangelog(ctx context.Context, content []byte) (*chg.Changelog, error) {
+	doc, err := changelog.Read(ctx, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	return doc.Changelog, nil
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rcmachado/changelog/config"
+	"github.com/rcmachado/changelog/lockfile"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestWorkspace creates a workspace with packages "a" and "b"
+func newTestWorkspace(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "changelog-workspace")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := ioutil.ReadFile("testdata/show-changelog.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		pkgDir := filepath.Join(dir, name)
+		if err := os.Mkdir(pkgDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(pkgDir, "CHANGELOG.md"), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func executeWorkspaceCmd(cfg *config.Config, args ...string) (string, error) {
+	out := new(bytes.Buffer)
+	iostreams := &IOStreams{Out: out}
+
+	cmd := newWorkspaceCmd(iostreams, cfg)
+	cmd.SetArgs(args)
+	_, err := cmd.ExecuteC()
+
+	return out.String(), err
+}
+
+func TestWorkspaceShowCmd(t *testing.T) {
+	dir, cleanup := newTestWorkspace(t)
+	defer cleanup()
+
+	pattern := filepath.Join(dir, "*", "CHANGELOG.md")
+	out, err := executeWorkspaceCmd(config.Default(), "show", "Unreleased", "--pattern", pattern)
+
+	expected := "==> a (" + filepath.Join(dir, "a", "CHANGELOG.md") + ")\n" +
+		"### Removed\n- Item 4\n" +
+		"==> b (" + filepath.Join(dir, "b", "CHANGELOG.md") + ")\n" +
+		"### Removed\n- Item 4\n"
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, out)
+}
+
+func TestWorkspaceLintCmd(t *testing.T) {
+	dir, cleanup := newTestWorkspace(t)
+	defer cleanup()
+
+	broken := filepath.Join(dir, "b", "CHANGELOG.md")
+	ioutil.WriteFile(broken, []byte("# Changelog\n\n## 1.0.0\n"), 0644)
+
+	cfg := config.Default()
+	cfg.Workspace.Patterns = []string{filepath.Join(dir, "*", "CHANGELOG.md")}
+
+	out, err := executeWorkspaceCmd(cfg, "lint")
+
+	assert.Error(t, err)
+	assert.Contains(t, out, "==> b ("+broken+")\nmissing Unreleased version\n1.0.0: missing release date\nerror: found 2 problem(s)\n")
+}
+
+func TestWorkspaceFmtCmd(t *testing.T) {
+	dir, cleanup := newTestWorkspace(t)
+	defer cleanup()
+
+	messy := filepath.Join(dir, "a", "CHANGELOG.md")
+	ioutil.WriteFile(messy, []byte("# Changelog\n## [Unreleased]\n### Fixed\n* Item\n"), 0644)
+	pattern := filepath.Join(dir, "*", "CHANGELOG.md")
+
+	t.Run("check", func(t *testing.T) {
+		_, err := executeWorkspaceCmd(config.Default(), "fmt", "--check", "--pattern", pattern)
+		assert.Error(t, err)
+	})
+
+	t.Run("write", func(t *testing.T) {
+		out, err := executeWorkspaceCmd(config.Default(), "fmt", "--pattern", pattern)
+		assert.NoError(t, err)
+		assert.Contains(t, out, "reformatted")
+
+		content, _ := ioutil.ReadFile(messy)
+		assert.Equal(t, "# Changelog\n\n## Unreleased\n### Fixed\n- Item\n", string(content))
+	})
+}
+
+func TestWorkspaceReleaseCmd(t *testing.T) {
+	dir, cleanup := newTestWorkspace(t)
+	defer cleanup()
+
+	cfg := config.Default()
+	cfg.Workspace.Packages = []config.Package{
+		{Name: "pkg-a", Path: filepath.Join(dir, "a", "CHANGELOG.md"), TagPrefix: "{name}@"},
+		{Name: "pkg-b", Path: filepath.Join(dir, "b", "CHANGELOG.md")},
+	}
+	cfg.Guard.Lockfile = ".changelog.lock"
+
+	out, err := executeWorkspaceCmd(cfg, "release", "pkg-a=1.1.0", "--release-date", "2020-08-01")
+	assert.NoError(t, err)
+	assert.Contains(t, out, "released pkg-a@1.1.0")
+	assert.NotContains(t, out, "pkg-b")
+
+	content, _ := ioutil.ReadFile(filepath.Join(dir, "a", "CHANGELOG.md"))
+	assert.Contains(t, string(content), "## [1.1.0] - 2020-08-01")
+	assert.Contains(t, string(content), "[Unreleased]: https://github.com/rcmachado/changelog/compare/pkg-a@1.1.0...HEAD")
+	assert.Contains(t, string(content), "[1.1.0]: https://github.com/rcmachado/changelog/compare/1.0.0...pkg-a@1.1.0")
+
+	lock, err := lockfile.Load(filepath.Join(dir, "a", ".changelog.lock"))
+	assert.NoError(t, err)
+	if assert.Len(t, lock.Versions, 2) {
+		assert.Equal(t, "1.1.0", lock.Versions[0].Version)
+	}
+	assert.NoFileExists(t, filepath.Join(dir, "b", ".changelog.lock"))
+
+	t.Run("unknown-package", func(t *testing.T) {
+		_, err := executeWorkspaceCmd(cfg, "release", "pkg-c=1.0.0")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid-argument", func(t *testing.T) {
+		_, err := executeWorkspaceCmd(cfg, "release", "1.0.0")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid-date", func(t *testing.T) {
+		_, err := executeWorkspaceCmd(cfg, "release", "pkg-b=1.1.0", "--release-date", "someday")
+		assert.EqualError(t, err, "Invalid release date 'someday', use 'today', 'yesterday' or a date like 2024-01-31")
+
+		content, _ := ioutil.ReadFile(filepath.Join(dir, "b", "CHANGELOG.md"))
+		assert.NotContains(t, string(content), "## [1.1.0]")
+	})
+
+	t.Run("date-format", func(t *testing.T) {
+		_, err := executeWorkspaceCmd(cfg, "release", "pkg-b=1.1.0", "--release-date", "January 31, 2024")
+		assert.NoError(t, err)
+
+		content, _ := ioutil.ReadFile(filepath.Join(dir, "b", "CHANGELOG.md"))
+		assert.Contains(t, string(content), "## [1.1.0] - 2024-01-31")
+	})
+}
+
+func TestWorkspacePackagesUnique(t *testing.T) {
+	dir, cleanup := newTestWorkspace(t)
+	defer cleanup()
+
+	cfg := config.Default()
+	cfg.Workspace.Packages = []config.Package{
+		{Name: "pkg-b", Path: filepath.Join(dir, "b", "CHANGELOG.md")},
+	}
+	cfg.Workspace.Patterns = []string{filepath.Join(dir, "*", "CHANGELOG.md")}
+
+	out, err := executeWorkspaceCmd(cfg, "lint")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(out, "==> "))
+	assert.Contains(t, out, "==> pkg-b (")
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/rcmachado/changelog/chg"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DefaultFilename is the configuration file looked up in the current
+// directory when none is informed
+const DefaultFilename = ".changelog.yml"
+
+// Config holds the settings read from the configuration file
+type Config struct {
+	ChangeTypes []ChangeType `yaml:"change_types"`
+	Format      Format       `yaml:"format"`
+	Workspace   Workspace    `yaml:"workspace"`
+	Aggregate   Aggregate    `yaml:"aggregate"`
+	CheckPR     CheckPR      `yaml:"check_pr"`
+	Guard       Guard        `yaml:"guard"`
+	Serve       Serve        `yaml:"serve"`
+	Watch       Watch        `yaml:"watch"`
+}
+
+// ChangeType configures one section of the changelog
+type ChangeType struct {
+	Name    string   `yaml:"name"`
+	Heading string   `yaml:"heading"`
+	Aliases []string `yaml:"aliases"`
+}
+
+// Format configures the markdown style of the rendered changelog.
+// Empty values use the defaults of chg.DefaultStyle.
+type Format struct {
+	Wrap       int    `yaml:"wrap"`        // Maximum line width of the items, 0 disables wrapping
+	Bullet     string `yaml:"bullet"`      // "-", "*" or "+"
+	Emphasis   string `yaml:"emphasis"`    // "_" or "*"
+	BlankLines string `yaml:"blank_lines"` // "compact" or "spaced"
+	LinkStyle  string `yaml:"link_style"`  // "reference" or "inline"
+	DateFormat string `yaml:"date_format"` // Go layout of the dates, eg. "2006-01-02"
+}
+
+// Workspace lists the changelogs of a monorepo
+type Workspace struct {
+	Patterns  []string  `yaml:"patterns"`   // Glob patterns matching changelog files
+	TagPrefix string    `yaml:"tag_prefix"` // Tag prefix for discovered packages, eg. "{name}@"
+	Packages  []Package `yaml:"packages"`
+}
+
+// Package is a changelog explicitly listed in the workspace
+type Package struct {
+	Name      string `yaml:"name"`
+	Path      string `yaml:"path"`
+	TagPrefix string `yaml:"tag_prefix"`
+}
+
+// Aggregate describes how component changelogs are rolled up into a
+// product changelog
+type Aggregate struct {
+	Components []Component      `yaml:"components"`
+	Versions   []ProductVersion `yaml:"versions"` // Newest first
+}
+
+// Component is a changelog rolled up into the product changelog
+type Component struct {
+	Name string `yaml:"name"`
+	Path string `yaml:"path"`
+}
+
+// ProductVersion maps a product version to the component versions.
+// Without components, the versions are picked by date.
+type ProductVersion struct {
+	Name       string            `yaml:"name"`
+	Date       string            `yaml:"date"`
+	Link       string            `yaml:"link"`
+	Components map[string]string `yaml:"components"`
+}
+
+// CheckPR configures the pull request gate
+type CheckPR struct {
+	Include     []string `yaml:"include"`      // Paths that count as code changes; empty for all
+	Exclude     []string `yaml:"exclude"`      // Paths that don't count as code changes
+	SkipLabel   string   `yaml:"skip_label"`   // Pull request label that disables the check
+	SkipTrailer string   `yaml:"skip_trailer"` // Commit trailer that disables the check
+}
+
+// Guard configures the protection of released versions
+type Guard struct {
+	Lockfile string `yaml:"lockfile"` // Hashes of the released versions, updated by release
+}
+
+// Serve configures the HTTP API
+type Serve struct {
+	Address string `yaml:"address"` // Address to listen on, eg. "localhost:8080"
+	Path    string `yaml:"path"`    // Changelog file or directory of changelogs
+}
+
+// Watch configures the files regenerated by watch
+type Watch struct {
+	Paths   []string      `yaml:"paths"` // Other files or directories that trigger a rebuild
+	Outputs []WatchOutput `yaml:"outputs"`
+}
+
+// WatchOutput is a file generated from the changelog
+type WatchOutput struct {
+	Format string `yaml:"format"`
+	Path   string `yaml:"path"`
+}
+
+// Default returns the configuration used when there is no file
+func Default() *Config {
+	return &Config{
+		CheckPR: CheckPR{
+			SkipLabel:   "skip-changelog",
+			SkipTrailer: "Changelog: skip",
+		},
+		Serve: Serve{
+			Address: "localhost:8080",
+		},
+	}
+}
+
+// Parse reads the configuration from r
+func Parse(r io.Reader) (*Config, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	c := Default()
+	if err := yaml.UnmarshalStrict(content, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Load reads the configuration from filename. If the file doesn't
+// exist and optional is true, the default configuration is returned.
+func Load(filename string, optional bool) (*Config, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		if optional && os.IsNotExist(err) {
+			return Default(), nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// LoadAggregate reads an aggregate manifest from filename. Relative
+// component paths are resolved from the directory of the manifest.
+func LoadAggregate(filename string) (*Aggregate, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Aggregate{}
+	if err := yaml.UnmarshalStrict(content, a); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(filename)
+	for idx, c := range a.Components {
+		if !filepath.IsAbs(c.Path) {
+			a.Components[idx].Path = filepath.Join(dir, c.Path)
+		}
+	}
+	return a, nil
+}
+
+// ProductVersions converts the versions to the format used by chg.Aggregate
+func (a *Aggregate) ProductVersions() []chg.ProductVersion {
+	versions := make([]chg.ProductVersion, len(a.Versions))
+	for idx, v := range a.Versions {
+		versions[idx] = chg.ProductVersion{
+			Name:       v.Name,
+			Date:       v.Date,
+			Link:       v.Link,
+			Components: v.Components,
+		}
+	}
+	return versions
+}
+
+// ChangeTypeDefs returns the configured change types, falling back
+// to chg.DefaultChangeTypes
+func (c *Config) ChangeTypeDefs() []chg.ChangeTypeDef {
+	if len(c.ChangeTypes) == 0 {
+		return chg.DefaultChangeTypes
+	}
+
+	defs := make([]chg.ChangeTypeDef, len(c.ChangeTypes))
+	for idx, ct := range c.ChangeTypes {
+		defs[idx] = chg.ChangeTypeDef{
+			Name:    ct.Name,
+			Heading: ct.Heading,
+			Aliases: ct.Aliases,
+		}
+	}
+	return defs
+}
+
+// Style returns the configured format in the form used by chg.SetStyle
+func (c *Config) Style() chg.Style {
+	return chg.Style{
+		Wrap:       c.Format.Wrap,
+		Bullet:     c.Format.Bullet,
+		Emphasis:   c.Format.Emphasis,
+		BlankLines: c.Format.BlankLines,
+		LinkStyle:  c.Format.LinkStyle,
+		DateFormat: c.Format.DateFormat,
+	}
+}
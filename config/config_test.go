@@ -0,0 +1,141 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	input := `change_types:
+  - name: Added
+    heading: Adicionado
+  - name: Fixed
+    aliases: [Bugfixes]
+  - name: Performance
+`
+	c, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	expected := []chg.ChangeTypeDef{
+		{Name: "Added", Heading: "Adicionado"},
+		{Name: "Fixed", Aliases: []string{"Bugfixes"}},
+		{Name: "Performance"},
+	}
+	assert.Equal(t, expected, c.ChangeTypeDefs())
+}
+
+func TestParseUnknownField(t *testing.T) {
+	_, err := Parse(strings.NewReader("unknown: true\n"))
+	assert.Error(t, err)
+}
+
+func TestDefaultChangeTypes(t *testing.T) {
+	assert.Equal(t, chg.DefaultChangeTypes, Default().ChangeTypeDefs())
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("optional-missing", func(t *testing.T) {
+		c, err := Load("testdata/missing.yml", true)
+		assert.NoError(t, err)
+		assert.Equal(t, Default(), c)
+	})
+
+	t.Run("required-missing", func(t *testing.T) {
+		_, err := Load("testdata/missing.yml", false)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseWorkspace(t *testing.T) {
+	input := `workspace:
+  patterns: ["packages/*/CHANGELOG.md"]
+  tag_prefix: "{name}@"
+  packages:
+    - name: root
+      path: CHANGELOG.md
+      tag_prefix: v
+`
+	c, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	expected := Workspace{
+		Patterns:  []string{"packages/*/CHANGELOG.md"},
+		TagPrefix: "{name}@",
+		Packages: []Package{
+			{Name: "root", Path: "CHANGELOG.md", TagPrefix: "v"},
+		},
+	}
+	assert.Equal(t, expected, c.Workspace)
+}
+
+func TestLoadAggregate(t *testing.T) {
+	a, err := LoadAggregate("testdata/aggregate.yml")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Component{{Name: "api", Path: filepath.Join("testdata", "api", "CHANGELOG.md")}}, a.Components)
+
+	expected := []chg.ProductVersion{
+		{Name: "2.0.0", Date: "2020-08-01", Components: map[string]string{"api": "1.1.0"}},
+		{Name: "1.0.0", Date: "2020-06-02"},
+	}
+	assert.Equal(t, expected, a.ProductVersions())
+}
+
+func TestParseCheckPR(t *testing.T) {
+	input := `check_pr:
+  include: ["src/**"]
+  skip_label: no-changelog
+`
+	c, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	expected := CheckPR{
+		Include:     []string{"src/**"},
+		SkipLabel:   "no-changelog",
+		SkipTrailer: "Changelog: skip",
+	}
+	assert.Equal(t, expected, c.CheckPR)
+}
+
+func TestParseServe(t *testing.T) {
+	input := `serve:
+  path: docs/
+`
+	c, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	assert.Equal(t, Serve{Address: "localhost:8080", Path: "docs/"}, c.Serve)
+}
+
+func TestParseWatch(t *testing.T) {
+	input := `watch:
+  paths: [changes/]
+  outputs:
+    - format: html
+      path: docs/changelog.html
+`
+	c, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	expected := Watch{
+		Paths:   []string{"changes/"},
+		Outputs: []WatchOutput{{Format: "html", Path: "docs/changelog.html"}},
+	}
+	assert.Equal(t, expected, c.Watch)
+}
+
+func TestParseFormat(t *testing.T) {
+	input := `format:
+  wrap: 80
+  bullet: "*"
+  blank_lines: spaced
+  date_format: 02/01/2006
+`
+	c, err := Parse(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Equal(t, chg.Style{Wrap: 80, Bullet: "*", BlankLines: "spaced", DateFormat: "02/01/2006"}, c.Style())
+}
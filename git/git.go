@@ -0,0 +1,106 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Repo runs git commands in a local repository
+type Repo struct {
+	Dir string // Directory inside the repository; empty for the current directory
+}
+
+// Show returns the contents of path at revision rev. Relative paths
+// are resolved from the repository directory.
+func (r *Repo) Show(rev, path string) ([]byte, error) {
+	if filepath.IsAbs(path) {
+		dir, err := filepath.Abs(r.Dir)
+		if err != nil {
+			return nil, err
+		}
+		if path, err = filepath.Rel(dir, path); err != nil {
+			return nil, err
+		}
+	}
+
+	spec := fmt.Sprintf("%s:./%s", rev, filepath.ToSlash(path))
+	return r.run("show", spec)
+}
+
+func (r *Repo) run(args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %s", args[0], err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// MergeBase returns the best common ancestor of revisions a and b
+func (r *Repo) MergeBase(a, b string) (string, error) {
+	out, err := r.run("merge-base", a, b)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ChangedFiles lists the files changed between rev and the working
+// tree, including the untracked ones not ignored by git. Paths are
+// relative to the top of the repository.
+func (r *Repo) ChangedFiles(rev string) ([]string, error) {
+	out, err := r.run("diff", "--name-only", "-z", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := r.run("ls-files", "--others", "--exclude-standard", "--full-name", "-z", "--", ":/")
+	if err != nil {
+		return nil, err
+	}
+
+	files := splitNull(out)
+	seen := make(map[string]bool)
+	for _, f := range files {
+		seen[f] = true
+	}
+	for _, f := range splitNull(untracked) {
+		if !seen[f] {
+			files = append(files, f)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// CommitMessages returns the messages of the commits reachable from
+// HEAD but not from rev
+func (r *Repo) CommitMessages(rev string) ([]string, error) {
+	out, err := r.run("log", "-z", "--format=%B", rev+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return splitNull(out), nil
+}
+
+func splitNull(out []byte) []string {
+	var result []string
+	for _, s := range strings.Split(string(out), "\x00") {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
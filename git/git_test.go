@@ -0,0 +1,128 @@
+package git
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRepo creates a repository with a commit for each content of
+// CHANGELOG.md
+func newTestRepo(t *testing.T, contents ...string) (*Repo, func()) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir, err := ioutil.TempDir("", "changelog-git")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &Repo{Dir: dir}
+	gitRun := func(args ...string) {
+		if _, err := r.run(args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gitRun("init", "-q")
+	gitRun("config", "user.email", "test@example.com")
+	gitRun("config", "user.name", "Test")
+
+	for idx, content := range contents {
+		if err := ioutil.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		gitRun("add", "CHANGELOG.md")
+		gitRun("commit", "-q", "-m", fmt.Sprintf("Update changelog\n\nCommit: %d", idx+1))
+	}
+
+	return r, func() { os.RemoveAll(dir) }
+}
+
+func TestRepoShow(t *testing.T) {
+	r, cleanup := newTestRepo(t, "first\n", "second\n")
+	defer cleanup()
+
+	t.Run("head", func(t *testing.T) {
+		content, err := r.Show("HEAD", "CHANGELOG.md")
+		assert.NoError(t, err)
+		assert.Equal(t, "second\n", string(content))
+	})
+
+	t.Run("previous", func(t *testing.T) {
+		content, err := r.Show("HEAD~1", "CHANGELOG.md")
+		assert.NoError(t, err)
+		assert.Equal(t, "first\n", string(content))
+	})
+
+	t.Run("absolute-path", func(t *testing.T) {
+		content, err := r.Show("HEAD", filepath.Join(r.Dir, "CHANGELOG.md"))
+		assert.NoError(t, err)
+		assert.Equal(t, "second\n", string(content))
+	})
+
+	t.Run("unknown-revision", func(t *testing.T) {
+		_, err := r.Show("unknown", "CHANGELOG.md")
+		assert.Error(t, err)
+	})
+}
+
+func TestRepoMergeBase(t *testing.T) {
+	r, cleanup := newTestRepo(t, "first\n", "second\n")
+	defer cleanup()
+
+	first, err := r.run("rev-parse", "HEAD~1")
+	assert.NoError(t, err)
+
+	base, err := r.MergeBase("HEAD~1", "HEAD")
+	assert.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(string(first)), base)
+}
+
+func TestRepoChangedFiles(t *testing.T) {
+	r, cleanup := newTestRepo(t, "first\n", "second\n")
+	defer cleanup()
+
+	ioutil.WriteFile(filepath.Join(r.Dir, "main.go"), []byte("package main\n"), 0644)
+	r.run("add", "main.go")
+
+	files, err := r.ChangedFiles("HEAD~1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"CHANGELOG.md", "main.go"}, files)
+
+	files, err = r.ChangedFiles("HEAD")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main.go"}, files)
+
+	t.Run("untracked", func(t *testing.T) {
+		os.Mkdir(filepath.Join(r.Dir, "pkg"), 0755)
+		ioutil.WriteFile(filepath.Join(r.Dir, "pkg", "new.go"), []byte("package pkg\n"), 0644)
+		ioutil.WriteFile(filepath.Join(r.Dir, "ignored.log"), []byte("log\n"), 0644)
+		ioutil.WriteFile(filepath.Join(r.Dir, ".gitignore"), []byte("*.log\n"), 0644)
+
+		files, err := r.ChangedFiles("HEAD")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{".gitignore", "main.go", "pkg/new.go"}, files)
+
+		sub := &Repo{Dir: filepath.Join(r.Dir, "pkg")}
+		files, err = sub.ChangedFiles("HEAD")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{".gitignore", "main.go", "pkg/new.go"}, files)
+	})
+}
+
+func TestRepoCommitMessages(t *testing.T) {
+	r, cleanup := newTestRepo(t, "first\n", "second\n", "third\n")
+	defer cleanup()
+
+	messages, err := r.CommitMessages("HEAD~2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Update changelog\n\nCommit: 3", "Update changelog\n\nCommit: 2"}, messages)
+}
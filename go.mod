@@ -3,6 +3,7 @@ module github.com/rcmachado/changelog
 go 1.13
 
 require (
+	github.com/fsnotify/fsnotify v1.4.9
 	github.com/jstemmer/go-junit-report v0.9.1
 	github.com/mattn/goveralls v0.0.5
 	github.com/russross/blackfriday/v2 v2.0.1
@@ -10,4 +11,5 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.5.1
 	golang.org/x/tools v0.0.0-20200515220128-d3bf790afa53
+	gopkg.in/yaml.v2 v2.2.2
 )
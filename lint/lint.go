@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// Problem describes an issue found in the changelog
+type Problem struct {
+	Version string // Version where the problem was found, if any
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Version == "" {
+		return p.Message
+	}
+	return fmt.Sprintf("%s: %s", p.Version, p.Message)
+}
+
+// Check validates the changelog against keepachangelog.com conventions
+func Check(c *chg.Changelog) []Problem {
+	var problems []Problem
+
+	unreleased := c.Version("Unreleased")
+	if unreleased == nil {
+		problems = append(problems, Problem{Message: "missing Unreleased version"})
+	} else if c.Versions[0] != unreleased {
+		problems = append(problems, Problem{
+			Version: unreleased.Name,
+			Message: "Unreleased should be the first version",
+		})
+	}
+
+	problems = append(problems, undefinedReferences(c, "", chg.References(c.Preamble))...)
+
+	seen := make(map[string]bool)
+	for _, v := range c.Versions {
+		name := strings.ToLower(v.Name)
+		if seen[name] {
+			problems = append(problems, Problem{Version: v.Name, Message: "duplicated version"})
+		}
+		seen[name] = true
+
+		if v == unreleased {
+			if v.Date != "" {
+				problems = append(problems, Problem{Version: v.Name, Message: "Unreleased should not have a release date"})
+			}
+		} else if v.Date == "" {
+			problems = append(problems, Problem{Version: v.Name, Message: "missing release date"})
+		} else if _, err := v.ReleaseTime(); err != nil {
+			problems = append(problems, Problem{Version: v.Name, Message: fmt.Sprintf("invalid release date '%s'", v.Date)})
+		}
+
+		for _, change := range v.Changes {
+			if len(change.Items) == 0 {
+				problems = append(problems, Problem{
+					Version: v.Name,
+					Message: fmt.Sprintf("empty section '%s'", change.Type),
+				})
+			}
+		}
+
+		problems = append(problems, undefinedReferences(c, v.Name, v.References())...)
+	}
+
+	for _, l := range c.UnusedLinks() {
+		problems = append(problems, Problem{Message: fmt.Sprintf("unused link definition '[%s]'", l.Label)})
+	}
+
+	return problems
+}
+
+// undefinedReferences reports the references without definition.
+// Shortcut references (eg. "[WIP]") are plain text when undefined, so
+// they aren't reported.
+func undefinedReferences(c *chg.Changelog, version string, refs []chg.Reference) []Problem {
+	var problems []Problem
+	for _, ref := range refs {
+		if ref.Shortcut || c.Link(ref.Label) != nil {
+			continue
+		}
+		problems = append(problems, Problem{
+			Version: version,
+			Message: fmt.Sprintf("undefined link reference '[%s]'", ref.Label),
+		})
+	}
+	return problems
+}
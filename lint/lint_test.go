@@ -0,0 +1,108 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		c := &chg.Changelog{
+			Versions: []*chg.Version{
+				{Name: "Unreleased"},
+				{
+					Name: "1.0.0",
+					Date: "2020-01-01",
+					Changes: []*chg.ChangeList{
+						{Type: chg.Added, Items: []*chg.Item{{Description: "Item"}}},
+					},
+				},
+			},
+		}
+		assert.Empty(t, Check(c))
+	})
+
+	t.Run("missing-unreleased", func(t *testing.T) {
+		c := &chg.Changelog{
+			Versions: []*chg.Version{
+				{Name: "1.0.0", Date: "2020-01-01"},
+			},
+		}
+		expected := []Problem{
+			{Message: "missing Unreleased version"},
+		}
+		assert.Equal(t, expected, Check(c))
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		c := &chg.Changelog{
+			Versions: []*chg.Version{
+				{Name: "1.0.0"},
+				{Name: "Unreleased", Date: "2020-01-01"},
+				{
+					Name: "1.0.0",
+					Date: "2020-01-01",
+					Changes: []*chg.ChangeList{
+						{Type: chg.Fixed},
+					},
+				},
+			},
+		}
+		expected := []Problem{
+			{Version: "Unreleased", Message: "Unreleased should be the first version"},
+			{Version: "1.0.0", Message: "missing release date"},
+			{Version: "Unreleased", Message: "Unreleased should not have a release date"},
+			{Version: "1.0.0", Message: "duplicated version"},
+			{Version: "1.0.0", Message: "empty section 'Fixed'"},
+		}
+		assert.Equal(t, expected, Check(c))
+	})
+}
+
+func TestCheckInvalidDate(t *testing.T) {
+	c := &chg.Changelog{
+		Versions: []*chg.Version{
+			{Name: "Unreleased"},
+			{Name: "1.1.0", Date: "31/01/2024"},
+			{Name: "1.0.0", Date: "2024-02-30"},
+		},
+	}
+	expected := []Problem{
+		{Version: "1.0.0", Message: "invalid release date '2024-02-30'"},
+	}
+	assert.Equal(t, expected, Check(c))
+}
+
+func TestCheckLinks(t *testing.T) {
+	c := &chg.Changelog{
+		Preamble: "See the [guide][contributing] and [docs][]",
+		Versions: []*chg.Version{
+			{
+				Name: "Unreleased",
+				Changes: []*chg.ChangeList{
+					{Type: chg.Fixed, Items: []*chg.Item{
+						{Description: "Crash [#1] ([report][#2]) [WIP]"},
+					}},
+				},
+			},
+		},
+		Links: []*chg.LinkDef{
+			{Label: "#1", URL: "https://example.com/issues/1"},
+			{Label: "docs", URL: "https://example.com/docs"},
+			{Label: "#3", URL: "https://example.com/issues/3"},
+		},
+	}
+	expected := []Problem{
+		{Message: "undefined link reference '[contributing]'"},
+		{Version: "Unreleased", Message: "undefined link reference '[#2]'"},
+		{Message: "unused link definition '[#3]'"},
+	}
+	assert.Equal(t, expected, Check(c))
+}
+
+func TestProblemString(t *testing.T) {
+	assert.Equal(t, "missing Unreleased version", Problem{Message: "missing Unreleased version"}.String())
+	assert.Equal(t, "1.0.0: missing release date", Problem{Version: "1.0.0", Message: "missing release date"}.String())
+}
@@ -0,0 +1,196 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/lint"
+)
+
+const header = "# Generated by changelog. Do not edit."
+
+// Lockfile stores a hash for each released version, so changes to
+// them can be detected, and the justifications for the allowed ones
+type Lockfile struct {
+	Versions       []Entry
+	Justifications []Justification
+}
+
+// Entry is the hash of a released version
+type Entry struct {
+	Version string
+	Hash    string
+}
+
+// Justification records why a released version was allowed to change
+type Justification struct {
+	Version string
+	Date    string
+	Reason  string
+}
+
+// New creates a lockfile with the hashes of the released versions
+func New(c *chg.Changelog) *Lockfile {
+	l := &Lockfile{}
+	l.Update(c)
+	return l
+}
+
+// Update replaces the hashes with the ones from the released versions
+// of c, keeping the justifications
+func (l *Lockfile) Update(c *chg.Changelog) {
+	l.Versions = nil
+	for _, v := range c.Versions {
+		if !v.IsUnreleased() {
+			l.Versions = append(l.Versions, Entry{Version: v.Name, Hash: v.Hash()})
+		}
+	}
+}
+
+// Hashes returns the hashes of the released versions of c, by name.
+// Take them before an edit of the changelog to Sync the lockfile after.
+func Hashes(c *chg.Changelog) map[string]string {
+	hashes := make(map[string]string)
+	for _, v := range c.Versions {
+		if !v.IsUnreleased() {
+			hashes[v.Name] = v.Hash()
+		}
+	}
+	return hashes
+}
+
+// Sync updates the hashes of the versions changed by an edit of c,
+// before being the hashes from before the edit. New versions are added
+// and removed ones dropped. The other versions keep the recorded hash,
+// so Check still reports the changes made outside of the edit.
+func (l *Lockfile) Sync(before map[string]string, c *chg.Changelog) {
+	recorded := make(map[string]string)
+	for _, e := range l.Versions {
+		recorded[e.Version] = e.Hash
+	}
+
+	var entries []Entry
+	for _, v := range c.Versions {
+		if v.IsUnreleased() {
+			continue
+		}
+		hash := v.Hash()
+		if previous, ok := before[v.Name]; ok && previous == hash {
+			if locked, ok := recorded[v.Name]; ok {
+				entries = append(entries, Entry{Version: v.Name, Hash: locked})
+			}
+			continue
+		}
+		entries = append(entries, Entry{Version: v.Name, Hash: hash})
+	}
+
+	for _, e := range l.Versions {
+		if _, removed := before[e.Version]; !removed && c.Version(e.Version) == nil {
+			entries = append(entries, e)
+		}
+	}
+	l.Versions = entries
+}
+
+// Allow records the justification for a change to a released version
+func (l *Lockfile) Allow(version, date, reason string) {
+	l.Justifications = append(l.Justifications, Justification{
+		Version: version,
+		Date:    date,
+		Reason:  strings.Join(strings.Fields(reason), " "),
+	})
+}
+
+// Check compares the released versions of c with the hashes, returning
+// the ones that were modified or removed. Versions released after the
+// lockfile was generated are ignored.
+func (l *Lockfile) Check(c *chg.Changelog) []lint.Problem {
+	var problems []lint.Problem
+	for _, entry := range l.Versions {
+		v := c.Version(entry.Version)
+		if v == nil {
+			problems = append(problems, lint.Problem{Version: entry.Version, Message: "released version was removed"})
+		} else if v.Hash() != entry.Hash {
+			problems = append(problems, lint.Problem{Version: entry.Version, Message: "released version was modified"})
+		}
+	}
+	return problems
+}
+
+// Parse reads a lockfile
+func Parse(r io.Reader) (*Lockfile, error) {
+	l := &Lockfile{}
+
+	scanner := bufio.NewScanner(r)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "# allowed ") {
+			// # allowed <version> on <date>: <reason>
+			var j Justification
+			fields := strings.SplitN(strings.TrimPrefix(line, "# allowed "), ": ", 2)
+			if len(fields) == 2 {
+				j.Reason = fields[1]
+			}
+			if _, err := fmt.Sscanf(fields[0], "%s on %s", &j.Version, &j.Date); err != nil {
+				return nil, fmt.Errorf("line %d: invalid justification", lineno)
+			}
+			l.Justifications = append(l.Justifications, j)
+			continue
+		}
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected '<version> <hash>'", lineno)
+		}
+		l.Versions = append(l.Versions, Entry{Version: fields[0], Hash: fields[1]})
+	}
+
+	return l, scanner.Err()
+}
+
+// Load reads the lockfile from filename
+func Load(filename string) (*Lockfile, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(f)
+}
+
+// Render writes the lockfile contents
+func (l *Lockfile) Render(w io.Writer) {
+	io.WriteString(w, header+"\n")
+	for _, e := range l.Versions {
+		fmt.Fprintf(w, "%s %s\n", e.Version, e.Hash)
+	}
+	for _, j := range l.Justifications {
+		fmt.Fprintf(w, "# allowed %s on %s: %s\n", j.Version, j.Date, j.Reason)
+	}
+}
+
+// Save writes the lockfile to filename
+func (l *Lockfile) Save(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	l.Render(w)
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
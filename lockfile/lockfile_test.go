@@ -0,0 +1,142 @@
+package lockfile
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/lint"
+	"github.com/stretchr/testify/assert"
+)
+
+func newChangelog() *chg.Changelog {
+	return &chg.Changelog{
+		Versions: []*chg.Version{
+			{Name: "Unreleased"},
+			{
+				Name: "1.0.0",
+				Date: "2020-01-01",
+				Changes: []*chg.ChangeList{
+					{Type: chg.Added, Items: []*chg.Item{{Description: "Item"}}},
+				},
+			},
+			{Name: "0.1.0", Date: "2019-01-01"},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	c := newChangelog()
+	l := New(c)
+
+	expected := []Entry{
+		{Version: "1.0.0", Hash: c.Versions[1].Hash()},
+		{Version: "0.1.0", Hash: c.Versions[2].Hash()},
+	}
+	assert.Equal(t, expected, l.Versions)
+}
+
+func TestCheck(t *testing.T) {
+	l := New(newChangelog())
+
+	t.Run("unchanged", func(t *testing.T) {
+		c := newChangelog()
+		c.Versions[0].Changes = []*chg.ChangeList{
+			{Type: chg.Fixed, Items: []*chg.Item{{Description: "Unreleased items can change"}}},
+		}
+		assert.Empty(t, l.Check(c))
+	})
+
+	t.Run("new-release", func(t *testing.T) {
+		c := newChangelog()
+		c.Versions = append([]*chg.Version{{Name: "Unreleased"}, {Name: "1.1.0", Date: "2020-02-01"}}, c.Versions[1:]...)
+		assert.Empty(t, l.Check(c))
+	})
+
+	t.Run("modified-and-removed", func(t *testing.T) {
+		c := newChangelog()
+		c.Versions[1].Changes[0].Items[0].Description = "Changed"
+		c.Versions = c.Versions[:2]
+
+		expected := []lint.Problem{
+			{Version: "1.0.0", Message: "released version was modified"},
+			{Version: "0.1.0", Message: "released version was removed"},
+		}
+		assert.Equal(t, expected, l.Check(c))
+	})
+}
+
+func TestSync(t *testing.T) {
+	l := New(newChangelog())
+	recorded := l.Versions[0].Hash
+
+	c := newChangelog()
+	c.Versions[1].Changes[0].Items[0].Description = "Changed before the edit"
+	before := Hashes(c)
+
+	// The edit releases 1.1.0 and renames 0.1.0
+	c.Versions = append([]*chg.Version{{Name: "Unreleased"}, {Name: "1.1.0", Date: "2020-02-01"}}, c.Versions[1:]...)
+	c.Versions[3].Name = "0.1.1"
+
+	l.Sync(before, c)
+
+	expected := []Entry{
+		{Version: "1.1.0", Hash: c.Versions[1].Hash()},
+		{Version: "1.0.0", Hash: recorded},
+		{Version: "0.1.1", Hash: c.Versions[3].Hash()},
+	}
+	assert.Equal(t, expected, l.Versions)
+	assert.Equal(t, []lint.Problem{{Version: "1.0.0", Message: "released version was modified"}}, l.Check(c))
+}
+
+func TestRenderAndParse(t *testing.T) {
+	l := &Lockfile{
+		Versions: []Entry{
+			{Version: "1.0.0", Hash: "sha256:abc"},
+			{Version: "0.1.0", Hash: "sha256:def"},
+		},
+	}
+	l.Allow("1.0.0", "2020-02-01", "Fix typo\nin the link")
+
+	expected := `# Generated by changelog. Do not edit.
+1.0.0 sha256:abc
+0.1.0 sha256:def
+# allowed 1.0.0 on 2020-02-01: Fix typo in the link
+`
+
+	var buf bytes.Buffer
+	l.Render(&buf)
+	assert.Equal(t, expected, buf.String())
+
+	parsed, err := Parse(strings.NewReader(expected))
+	assert.NoError(t, err)
+	assert.Equal(t, l, parsed)
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse(strings.NewReader("1.0.0\n"))
+	assert.Error(t, err)
+
+	_, err = Parse(strings.NewReader("# allowed 1.0.0: reason\n"))
+	assert.Error(t, err)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "changelog-lockfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, ".changelog.lock")
+	l := New(newChangelog())
+	assert.NoError(t, l.Save(filename))
+
+	loaded, err := Load(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, l, loaded)
+}
@@ -0,0 +1,389 @@
+package lsp
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/lint"
+	"github.com/rcmachado/changelog/parser"
+)
+
+const (
+	diagnosticSource = "changelog"
+	codeMissingLink  = "missing-link"
+)
+
+var (
+	reCompareLink     = regexp.MustCompile(`^(.*/compare/)(v?)[^/]*\.{2,3}[^/]*$`)
+	reHeadingPrefix   = regexp.MustCompile(`^ {0,3}#{1,6}[ \t]*[[:alpha:]]*$`)
+	reMissingLinkName = regexp.MustCompile(`^add link definition for (.+)$`)
+)
+
+// document is a parsed version of an open file
+type document struct {
+	text      string
+	lines     []string
+	changelog *chg.Changelog
+	sourceMap *parser.SourceMap
+}
+
+func newDocument(text string) (*document, error) {
+	c, m, err := parser.ParseWithSourceMap(strings.NewReader(text))
+	if err != nil {
+		return nil, err
+	}
+	return &document{
+		text:      text,
+		lines:     strings.Split(text, "\n"),
+		changelog: c,
+		sourceMap: m,
+	}, nil
+}
+
+// lineRange returns the range covering the lines from start to end
+func (d *document) lineRange(start, end int) Range {
+	return Range{
+		Start: Position{Line: start},
+		End:   Position{Line: end, Character: d.lineLength(end)},
+	}
+}
+
+func (d *document) lineLength(line int) int {
+	if line < 0 || line >= len(d.lines) {
+		return 0
+	}
+	return utf16Len(strings.TrimSuffix(d.lines[line], "\r"))
+}
+
+// endPosition is the position after the last character of the document
+func (d *document) endPosition() Position {
+	last := len(d.lines) - 1
+	return Position{Line: last, Character: utf16Len(d.lines[last])}
+}
+
+func (d *document) diagnostics() []Diagnostic {
+	diagnostics := []Diagnostic{}
+
+	for _, e := range d.sourceMap.Errors {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    d.lineRange(e.Line, e.Line),
+			Severity: SeverityError,
+			Source:   diagnosticSource,
+			Message:  e.Message,
+		})
+	}
+
+	for _, w := range d.sourceMap.Warnings {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    d.lineRange(w.Line, w.Line),
+			Severity: SeverityWarning,
+			Source:   diagnosticSource,
+			Message:  w.Message,
+		})
+	}
+
+	for _, p := range lint.Check(d.changelog) {
+		line := 0
+		if d.sourceMap.Title >= 0 {
+			line = d.sourceMap.Title
+		}
+		if v := d.changelog.Version(p.Version); v != nil {
+			line = d.sourceMap.Versions[v].Start
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    d.lineRange(line, line),
+			Severity: SeverityError,
+			Source:   diagnosticSource,
+			Message:  p.String(),
+		})
+	}
+
+	for _, v := range d.changelog.Versions {
+		span, ok := d.sourceMap.Versions[v]
+		if !ok || v.Link != "" || !strings.Contains(d.lines[span.Start], "["+v.Name+"]") {
+			continue
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    d.lineRange(span.Start, span.Start),
+			Severity: SeverityWarning,
+			Code:     codeMissingLink,
+			Source:   diagnosticSource,
+			Message:  fmt.Sprintf("add link definition for %s", v.Name),
+		})
+	}
+
+	return diagnostics
+}
+
+// symbols returns the versions and their sections for the outline
+func (d *document) symbols() []DocumentSymbol {
+	symbols := []DocumentSymbol{}
+
+	for _, v := range d.changelog.Versions {
+		span, ok := d.sourceMap.Versions[v]
+		if !ok {
+			continue
+		}
+
+		symbol := DocumentSymbol{
+			Name:           v.Name,
+			Detail:         v.Date,
+			Kind:           SymbolKindModule,
+			Range:          d.lineRange(span.Start, span.End),
+			SelectionRange: d.lineRange(span.Start, span.Start),
+		}
+		for _, change := range v.Changes {
+			for _, s := range d.sourceMap.Changes[change] {
+				symbol.Children = append(symbol.Children, DocumentSymbol{
+					Name:           change.Type.String(),
+					Detail:         fmt.Sprintf("%d items", len(change.Items)),
+					Kind:           SymbolKindEnum,
+					Range:          d.lineRange(s.Start, s.End),
+					SelectionRange: d.lineRange(s.Start, s.Start),
+				})
+			}
+		}
+		symbols = append(symbols, symbol)
+	}
+
+	return symbols
+}
+
+// completion proposes the change type headings when the line being
+// typed is a heading
+func (d *document) completion(pos Position) []CompletionItem {
+	items := []CompletionItem{}
+	if pos.Line < 0 || pos.Line >= len(d.lines) {
+		return items
+	}
+
+	prefix := utf16Prefix(d.lines[pos.Line], pos.Character)
+	if !reHeadingPrefix.MatchString(prefix) {
+		return items
+	}
+
+	for _, ct := range chg.ChangeTypes() {
+		heading := "### " + ct.String()
+		items = append(items, CompletionItem{
+			Label:  heading,
+			Kind:   CompletionItemKindKeyword,
+			Detail: "Change type",
+			TextEdit: &TextEdit{
+				Range: Range{
+					Start: Position{Line: pos.Line},
+					End:   pos,
+				},
+				NewText: heading,
+			},
+		})
+	}
+
+	return items
+}
+
+// format replaces the whole document with the rendered changelog.
+// Documents with syntax errors or content ignored by the parser aren't
+// formatted, as that content would be lost.
+func (d *document) format() []TextEdit {
+	if !d.rewritable() {
+		return []TextEdit{}
+	}
+	formatted := d.render()
+	if formatted == d.text {
+		return []TextEdit{}
+	}
+	return []TextEdit{d.replaceAll(formatted)}
+}
+
+// rewritable checks if the document can be replaced with the rendered
+// changelog without losing content
+func (d *document) rewritable() bool {
+	return len(d.sourceMap.Errors) == 0 && len(d.sourceMap.Warnings) == 0
+}
+
+func (d *document) render() string {
+	var buf bytes.Buffer
+	d.changelog.Render(&buf)
+	return buf.String()
+}
+
+func (d *document) replaceAll(text string) TextEdit {
+	return TextEdit{
+		Range:   Range{End: d.endPosition()},
+		NewText: text,
+	}
+}
+
+// codeActions returns the fixes for the diagnostics and the actions
+// available for the item in rng
+func (d *document) codeActions(uri string, rng Range, diagnostics []Diagnostic) []CodeAction {
+	actions := []CodeAction{}
+
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Code != codeMissingLink {
+			continue
+		}
+		if action := d.addLinkAction(uri, diagnostic); action != nil {
+			actions = append(actions, *action)
+		}
+	}
+
+	if !d.rewritable() {
+		// Moving items rewrites the whole document, see format
+		return actions
+	}
+
+	for vIdx, v := range d.changelog.Versions {
+		for cIdx, change := range v.Changes {
+			for iIdx, item := range change.Items {
+				span, ok := d.sourceMap.Items[item]
+				if !ok || rng.Start.Line < span.Start || rng.Start.Line > span.End {
+					continue
+				}
+				for _, ct := range chg.ChangeTypes() {
+					if ct == change.Type {
+						continue
+					}
+					actions = append(actions, CodeAction{
+						Title: fmt.Sprintf("Move item to %s", ct.String()),
+						Kind:  CodeActionRefactor,
+						Edit:  d.moveItemEdit(uri, vIdx, cIdx, iIdx, ct),
+					})
+				}
+				return actions
+			}
+		}
+	}
+
+	return actions
+}
+
+// addLinkAction appends the link definition for the version reported
+// by the diagnostic, inferring its URL from the other compare links
+func (d *document) addLinkAction(uri string, diagnostic Diagnostic) *CodeAction {
+	matches := reMissingLinkName.FindStringSubmatch(diagnostic.Message)
+	if matches == nil {
+		return nil
+	}
+
+	var (
+		idx     = -1
+		version *chg.Version
+	)
+	for i, v := range d.changelog.Versions {
+		if v.Name == matches[1] {
+			idx, version = i, v
+			break
+		}
+	}
+	if version == nil || version.Link != "" {
+		return nil
+	}
+
+	url := inferCompareLink(d.changelog, idx)
+	if url == "" {
+		return nil
+	}
+
+	text := fmt.Sprintf("[%s]: %s\n", version.Name, url)
+	if !strings.HasSuffix(d.text, "\n") {
+		text = "\n" + text
+	}
+	end := d.endPosition()
+
+	return &CodeAction{
+		Title:       fmt.Sprintf("Add link definition for %s", version.Name),
+		Kind:        CodeActionQuickFix,
+		Diagnostics: []Diagnostic{diagnostic},
+		Edit: &WorkspaceEdit{
+			Changes: map[string][]TextEdit{
+				uri: {{Range: Range{Start: end, End: end}, NewText: text}},
+			},
+		},
+	}
+}
+
+// moveItemEdit moves the item of the version at vIdx to the section
+// ct, creating it when needed, and rewrites the document. It works on
+// a copy of the changelog as each action is computed independently.
+func (d *document) moveItemEdit(uri string, vIdx, cIdx, iIdx int, ct chg.ChangeType) *WorkspaceEdit {
+	c := parser.ParseBytes([]byte(d.text))
+	v := c.Versions[vIdx]
+	from := v.Changes[cIdx]
+
+	item := from.Items[iIdx]
+	from.Items = append(from.Items[:iIdx], from.Items[iIdx+1:]...)
+	if len(from.Items) == 0 {
+		v.Changes = append(v.Changes[:cIdx], v.Changes[cIdx+1:]...)
+	}
+
+	to := v.Change(ct)
+	if to == nil {
+		to = &chg.ChangeList{Type: ct}
+		v.Changes = append(v.Changes, to)
+	}
+	to.Items = append(to.Items, item)
+
+	var buf bytes.Buffer
+	c.Render(&buf)
+
+	return &WorkspaceEdit{
+		Changes: map[string][]TextEdit{uri: {d.replaceAll(buf.String())}},
+	}
+}
+
+// inferCompareLink builds the compare link for the version at idx
+// following the format of the other versions' links
+func inferCompareLink(c *chg.Changelog, idx int) string {
+	if idx+1 >= len(c.Versions) {
+		return ""
+	}
+
+	for _, v := range c.Versions {
+		matches := reCompareLink.FindStringSubmatch(v.Link)
+		if matches == nil {
+			continue
+		}
+
+		base, prefix := matches[1], matches[2]
+		next := prefix + c.Versions[idx].Name
+		if c.Versions[idx].IsUnreleased() {
+			next = "HEAD"
+		}
+		return fmt.Sprintf("%s%s%s...%s", base, prefix, c.Versions[idx+1].Name, next)
+	}
+	return ""
+}
+
+// utf16Len is the length of s in UTF-16 code units, the unit used by
+// the positions
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// utf16Prefix returns the first n UTF-16 code units of s
+func utf16Prefix(s string, n int) string {
+	count := 0
+	for idx, r := range s {
+		if count >= n {
+			return s[:idx]
+		}
+		if r >= 0x10000 {
+			count += 2
+		} else {
+			count++
+		}
+	}
+	return s
+}
@@ -0,0 +1,207 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Server is a Language Server Protocol server for changelog files.
+// It keeps the open documents in memory and publishes diagnostics
+// whenever they change.
+type Server struct {
+	in        *bufio.Reader
+	out       io.Writer
+	documents map[string]string
+	shutdown  bool
+}
+
+// NewServer creates a server reading requests from in and writing
+// responses and notifications to out
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		in:        bufio.NewReader(in),
+		out:       out,
+		documents: make(map[string]string),
+	}
+}
+
+// Run handles the messages until the client sends "exit" or closes
+// the input
+func (s *Server) Run() error {
+	for {
+		body, err := readMessage(s.in)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var msg message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			if err := s.replyError(nil, codeParseError, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("exit without shutdown")
+			}
+			return nil
+		}
+
+		if err := s.handleSafely(&msg); err != nil {
+			return err
+		}
+	}
+}
+
+// handleSafely handles the message, turning a panic into an error
+// response, or a log message for notifications, so a document the
+// server can't handle doesn't stop it
+func (s *Server) handleSafely(msg *message) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		text := fmt.Sprintf("internal error handling %s: %v", msg.Method, r)
+		if msg.ID != nil {
+			err = s.replyError(msg.ID, codeInternalError, text)
+		} else {
+			err = s.notify("window/logMessage", logMessageParams{Type: MessageTypeError, Message: text})
+		}
+	}()
+
+	return s.handle(msg)
+}
+
+func (s *Server) handle(msg *message) error {
+	var (
+		result interface{}
+		err    error
+	)
+
+	switch msg.Method {
+	case "initialize":
+		result = map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":           1, // Full
+				"documentSymbolProvider":     true,
+				"documentFormattingProvider": true,
+				"codeActionProvider":         true,
+				"completionProvider": map[string]interface{}{
+					"triggerCharacters": []string{"#"},
+				},
+			},
+			"serverInfo": map[string]string{"name": "changelog"},
+		}
+	case "shutdown":
+		s.shutdown = true
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			return s.update(params.TextDocument.URI, params.TextDocument.Text)
+		}
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil && len(params.ContentChanges) > 0 {
+			text := params.ContentChanges[len(params.ContentChanges)-1].Text
+			return s.update(params.TextDocument.URI, text)
+		}
+	case "textDocument/didClose":
+		var params textDocumentParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			delete(s.documents, params.TextDocument.URI)
+			return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+				URI:         params.TextDocument.URI,
+				Diagnostics: []Diagnostic{},
+			})
+		}
+	case "textDocument/documentSymbol":
+		var params textDocumentParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			var doc *document
+			if doc, err = newDocument(s.documents[params.TextDocument.URI]); err == nil {
+				result = doc.symbols()
+			}
+		}
+	case "textDocument/completion":
+		var params positionParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			var doc *document
+			if doc, err = newDocument(s.documents[params.TextDocument.URI]); err == nil {
+				result = doc.completion(params.Position)
+			}
+		}
+	case "textDocument/formatting":
+		var params textDocumentParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			var doc *document
+			if doc, err = newDocument(s.documents[params.TextDocument.URI]); err == nil {
+				result = doc.format()
+			}
+		}
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			uri := params.TextDocument.URI
+			var doc *document
+			if doc, err = newDocument(s.documents[uri]); err == nil {
+				result = doc.codeActions(uri, params.Range, params.Context.Diagnostics)
+			}
+		}
+	default:
+		if msg.ID != nil {
+			return s.replyError(msg.ID, codeMethodNotFound, fmt.Sprintf("method not found: %s", msg.Method))
+		}
+		return nil
+	}
+
+	if msg.ID == nil {
+		// Notifications don't have responses
+		return nil
+	}
+	if err != nil {
+		return s.replyError(msg.ID, codeInvalidParams, err.Error())
+	}
+	return writeMessage(s.out, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      msg.ID,
+		"result":  result,
+	})
+}
+
+// update stores the document and publishes its diagnostics
+func (s *Server) update(uri, text string) error {
+	s.documents[uri] = text
+
+	doc, err := newDocument(text)
+	if err != nil {
+		return s.notify("window/logMessage", logMessageParams{Type: MessageTypeError, Message: err.Error()})
+	}
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: doc.diagnostics(),
+	})
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	return writeMessage(s.out, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+	})
+}
+
+func (s *Server) replyError(id *json.RawMessage, code int, msg string) error {
+	return writeMessage(s.out, map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"error":   responseError{Code: code, Message: msg},
+	})
+}
@@ -0,0 +1,283 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDocument = `# Changelog
+
+## [Unreleased]
+### Added
+- New feature
+
+### Fixed
+- Wrong fix
+
+## [1.0.0]
+### Added
+- First release
+
+[Unreleased]: https://github.com/rcmachado/changelog/compare/v1.0.0...HEAD
+`
+
+type response struct {
+	ID     int             `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+// runServer sends the messages to a server and returns everything it
+// wrote back
+func runServer(t *testing.T, messages ...interface{}) []response {
+	var in bytes.Buffer
+	for _, m := range messages {
+		assert.Nil(t, writeMessage(&in, m))
+	}
+
+	var out bytes.Buffer
+	assert.Nil(t, NewServer(&in, &out).Run())
+
+	var responses []response
+	r := bufio.NewReader(&out)
+	for {
+		body, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		var resp response
+		assert.Nil(t, json.Unmarshal(body, &resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func request(id int, method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "id": id, "method": method, "params": params}
+}
+
+func notification(method string, params interface{}) map[string]interface{} {
+	return map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+}
+
+func didOpen(text string) map[string]interface{} {
+	return notification("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]string{"uri": "file:///CHANGELOG.md", "text": text},
+	})
+}
+
+var testTextDocument = map[string]string{"uri": "file:///CHANGELOG.md"}
+
+func TestServerInitialize(t *testing.T) {
+	responses := runServer(t,
+		request(1, "initialize", map[string]interface{}{}),
+		notification("initialized", map[string]interface{}{}),
+		request(2, "unknown/method", nil),
+		request(3, "shutdown", nil),
+		notification("exit", nil),
+	)
+
+	assert.Len(t, responses, 3)
+	assert.Contains(t, string(responses[0].Result), `"documentSymbolProvider":true`)
+	assert.Equal(t, codeMethodNotFound, responses[1].Error.Code)
+	assert.Equal(t, "null", string(responses[2].Result))
+}
+
+func TestServerExitWithoutShutdown(t *testing.T) {
+	var in, out bytes.Buffer
+	writeMessage(&in, notification("exit", nil))
+
+	assert.NotNil(t, NewServer(&in, &out).Run())
+}
+
+func TestServerDiagnostics(t *testing.T) {
+	responses := runServer(t, didOpen(testDocument))
+
+	assert.Len(t, responses, 1)
+	assert.Equal(t, "textDocument/publishDiagnostics", responses[0].Method)
+
+	var params publishDiagnosticsParams
+	assert.Nil(t, json.Unmarshal(responses[0].Params, &params))
+	assert.Equal(t, []Diagnostic{
+		{
+			Range:    Range{Start: Position{Line: 9}, End: Position{Line: 9, Character: 10}},
+			Severity: SeverityError,
+			Source:   "changelog",
+			Message:  "1.0.0: missing release date",
+		},
+		{
+			Range:    Range{Start: Position{Line: 9}, End: Position{Line: 9, Character: 10}},
+			Severity: SeverityWarning,
+			Code:     "missing-link",
+			Source:   "changelog",
+			Message:  "add link definition for 1.0.0",
+		},
+	}, params.Diagnostics)
+}
+
+func TestServerIncompleteVersion(t *testing.T) {
+	responses := runServer(t,
+		didOpen("# Changelog\n\n## [\n"),
+		request(1, "textDocument/documentSymbol", map[string]interface{}{"textDocument": testTextDocument}),
+		request(2, "textDocument/formatting", map[string]interface{}{"textDocument": testTextDocument}),
+	)
+
+	assert.Len(t, responses, 3)
+
+	var params publishDiagnosticsParams
+	assert.Nil(t, json.Unmarshal(responses[0].Params, &params))
+	assert.Equal(t, Diagnostic{
+		Range:    Range{Start: Position{Line: 2}, End: Position{Line: 2, Character: 4}},
+		Severity: SeverityError,
+		Source:   "changelog",
+		Message:  "invalid version heading '['",
+	}, params.Diagnostics[0])
+
+	assert.Equal(t, "[]", string(responses[1].Result))
+	assert.Equal(t, "[]", string(responses[2].Result))
+}
+
+func TestServerDocumentSymbol(t *testing.T) {
+	responses := runServer(t,
+		didOpen(testDocument),
+		request(1, "textDocument/documentSymbol", map[string]interface{}{"textDocument": testTextDocument}),
+	)
+
+	var symbols []DocumentSymbol
+	assert.Nil(t, json.Unmarshal(responses[1].Result, &symbols))
+	assert.Len(t, symbols, 2)
+	assert.Equal(t, "Unreleased", symbols[0].Name)
+	assert.Equal(t, Range{Start: Position{Line: 2}, End: Position{Line: 7, Character: 11}}, symbols[0].Range)
+	assert.Len(t, symbols[0].Children, 2)
+	assert.Equal(t, "Fixed", symbols[0].Children[1].Name)
+	assert.Equal(t, "1 items", symbols[0].Children[1].Detail)
+}
+
+func TestServerCompletion(t *testing.T) {
+	text := "# Changelog\n\n## [Unreleased]\n##\n"
+	responses := runServer(t,
+		didOpen(text),
+		request(1, "textDocument/completion", map[string]interface{}{
+			"textDocument": testTextDocument,
+			"position":     Position{Line: 3, Character: 2},
+		}),
+		request(2, "textDocument/completion", map[string]interface{}{
+			"textDocument": testTextDocument,
+			"position":     Position{Line: 2, Character: 15},
+		}),
+	)
+
+	var items []CompletionItem
+	assert.Nil(t, json.Unmarshal(responses[1].Result, &items))
+	assert.Len(t, items, 6)
+	assert.Equal(t, "### Added", items[0].Label)
+	assert.Equal(t, Range{Start: Position{Line: 3}, End: Position{Line: 3, Character: 2}}, items[0].TextEdit.Range)
+
+	assert.Equal(t, "[]", string(responses[2].Result))
+}
+
+func TestServerFormatting(t *testing.T) {
+	responses := runServer(t,
+		didOpen(testDocument),
+		request(1, "textDocument/formatting", map[string]interface{}{"textDocument": testTextDocument}),
+	)
+
+	var edits []TextEdit
+	assert.Nil(t, json.Unmarshal(responses[1].Result, &edits))
+	assert.Len(t, edits, 1)
+	assert.Equal(t, Range{End: Position{Line: 14}}, edits[0].Range)
+	assert.Contains(t, edits[0].NewText, "\n## 1.0.0\n### Added\n")
+}
+
+func TestServerFormattingIgnoredContent(t *testing.T) {
+	text := strings.Replace(testDocument, "### Fixed\n", "### Performance\n", 1)
+	responses := runServer(t,
+		didOpen(text),
+		request(1, "textDocument/formatting", map[string]interface{}{"textDocument": testTextDocument}),
+		request(2, "textDocument/codeAction", map[string]interface{}{
+			"textDocument": testTextDocument,
+			"range":        Range{Start: Position{Line: 4}, End: Position{Line: 4}},
+			"context":      map[string]interface{}{"diagnostics": []Diagnostic{}},
+		}),
+	)
+
+	var edits []TextEdit
+	assert.Nil(t, json.Unmarshal(responses[1].Result, &edits))
+	assert.Empty(t, edits)
+
+	var actions []CodeAction
+	assert.Nil(t, json.Unmarshal(responses[2].Result, &actions))
+	assert.Empty(t, actions)
+}
+
+func TestServerCodeActionMoveItem(t *testing.T) {
+	responses := runServer(t,
+		didOpen(testDocument),
+		request(1, "textDocument/codeAction", map[string]interface{}{
+			"textDocument": testTextDocument,
+			"range":        Range{Start: Position{Line: 7}, End: Position{Line: 7}},
+			"context":      map[string]interface{}{"diagnostics": []Diagnostic{}},
+		}),
+	)
+
+	var actions []CodeAction
+	assert.Nil(t, json.Unmarshal(responses[1].Result, &actions))
+	assert.Len(t, actions, 5)
+	assert.Equal(t, "Move item to Added", actions[0].Title)
+
+	edit := actions[0].Edit.Changes["file:///CHANGELOG.md"][0]
+	assert.Contains(t, edit.NewText, "### Added\n- New feature\n- Wrong fix\n\n## 1.0.0")
+}
+
+func TestServerCodeActionAddLink(t *testing.T) {
+	diagnostic := Diagnostic{
+		Range:    Range{Start: Position{Line: 9}, End: Position{Line: 9, Character: 10}},
+		Severity: SeverityWarning,
+		Code:     "missing-link",
+		Source:   "changelog",
+		Message:  "add link definition for 1.0.0",
+	}
+	text := `# Changelog
+
+## [Unreleased]
+### Added
+- New feature
+
+### Fixed
+- Wrong fix
+
+## [1.0.0]
+### Added
+- First release
+
+## [0.9.0] - 2019-01-01
+### Added
+- Beta
+
+[Unreleased]: https://github.com/rcmachado/changelog/compare/v1.0.0...HEAD`
+
+	responses := runServer(t,
+		didOpen(text),
+		request(1, "textDocument/codeAction", map[string]interface{}{
+			"textDocument": testTextDocument,
+			"range":        diagnostic.Range,
+			"context":      map[string]interface{}{"diagnostics": []Diagnostic{diagnostic}},
+		}),
+	)
+
+	var actions []CodeAction
+	assert.Nil(t, json.Unmarshal(responses[1].Result, &actions))
+	assert.Len(t, actions, 1)
+	assert.Equal(t, "Add link definition for 1.0.0", actions[0].Title)
+	assert.Equal(t, []TextEdit{{
+		Range:   Range{Start: Position{Line: 17, Character: 74}, End: Position{Line: 17, Character: 74}},
+		NewText: "\n[1.0.0]: https://github.com/rcmachado/changelog/compare/v0.9.0...v1.0.0\n",
+	}}, actions[0].Edit.Changes["file:///CHANGELOG.md"])
+}
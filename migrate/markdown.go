@@ -0,0 +1,145 @@
+package migrate
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// Markdown imports markdown changelogs with version headings in other
+// formats, like "## v1.2.0 (2020-01-01)", and items with or without
+// sections
+type Markdown struct{}
+
+// GitHub imports the release notes generated by GitHub, with "What's
+// Changed" lists and "Full Changelog" links
+type GitHub struct{}
+
+var (
+	reATXHeading = regexp.MustCompile(`^ {0,3}#{1,6}\s+(.*?)(?:\s+#+)?\s*$`)
+	reListItem   = regexp.MustCompile(`^(\s*)[*+-]\s+(.*)$`)
+	reFence      = regexp.MustCompile("^\\s*(```|~~~)")
+
+	reWhatsChanged   = regexp.MustCompile(`(?im)^#{1,6}\s+What's Changed\s*$`)
+	reFullChangelog  = regexp.MustCompile(`^\*\*Full Changelog\*\*:\s*(\S+)\s*$`)
+	reGitHubItem     = regexp.MustCompile(`^(.*) by (@[\w\-\[\]]+) in (https://\S+/(?:pull|issues)/(\d+))$`)
+	reNewContributor = regexp.MustCompile(`(?i)^new contributors$`)
+)
+
+// Detect accepts any markdown with a version heading
+func (Markdown) Detect(content []byte) bool {
+	for _, line := range lines(content) {
+		if m := reATXHeading.FindStringSubmatch(line); m != nil && parseVersionTitle(m[1]) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Import converts the versions and items of the content
+func (Markdown) Import(content []byte, c *Classifier) (*chg.Changelog, error) {
+	return importMarkdown(content, c, false)
+}
+
+// Detect accepts release notes with "What's Changed" sections or "Full
+// Changelog" links
+func (GitHub) Detect(content []byte) bool {
+	return reWhatsChanged.Match(content) || bytes.Contains(content, []byte("**Full Changelog**"))
+}
+
+// Import converts the release notes, linking the pull requests and the
+// versions to their comparison URL
+func (GitHub) Import(content []byte, c *Classifier) (*chg.Changelog, error) {
+	return importMarkdown(content, c, true)
+}
+
+func importMarkdown(content []byte, c *Classifier, github bool) (*chg.Changelog, error) {
+	b := newBuilder(c)
+	inFence := false
+	blank := false
+
+	for _, line := range lines(content) {
+		if reFence.MatchString(line) {
+			// Code blocks aren't migrated
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			blank = true
+			continue
+		}
+
+		if m := reATXHeading.FindStringSubmatch(line); m != nil {
+			if v := parseVersionTitle(m[1]); v != nil {
+				b.startVersion(v)
+			} else {
+				b.startSection(m[1])
+				b.skip = github && reNewContributor.MatchString(m[1])
+			}
+			blank = false
+			continue
+		}
+
+		if m := reListItem.FindStringSubmatch(line); m != nil {
+			text := m[2]
+			if github {
+				text = githubItem(text)
+			}
+			if len(m[1]) >= 2 {
+				b.addChild(text)
+			} else {
+				b.addItem(text)
+			}
+			blank = false
+			continue
+		}
+
+		if github {
+			if m := reFullChangelog.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				if b.version != nil && b.version.Link == "" {
+					b.version.Link = m[1]
+				}
+				continue
+			}
+		}
+
+		// Text indented or right after an item continues it, otherwise
+		// it's a paragraph and isn't migrated
+		if !blank || strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t") {
+			b.continueItem(strings.TrimSpace(line))
+		} else {
+			b.item = nil
+		}
+		blank = false
+	}
+
+	return b.changelog, nil
+}
+
+// githubItem links the pull request of the item, eg. "Fix crash by
+// @user in https://github.com/o/r/pull/12" gets "[#12](...)"
+func githubItem(text string) string {
+	m := reGitHubItem.FindStringSubmatch(text)
+	if m == nil {
+		return text
+	}
+	return fmt.Sprintf("%s by %s in [#%s](%s)", m[1], m[2], m[4], m[3])
+}
+
+func lines(content []byte) []string {
+	var result []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for scanner.Scan() {
+		result = append(result, strings.TrimRight(scanner.Text(), " \t\r"))
+	}
+	return result
+}
@@ -0,0 +1,352 @@
+// Package migrate converts changelogs written in other styles to the
+// keepachangelog.com format
+package migrate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// Importer converts a changelog written in a specific style
+type Importer interface {
+	// Detect reports whether the content looks like the importer style
+	Detect(content []byte) bool
+	// Import converts the content, using the classifier to find the
+	// change type of the items
+	Import(content []byte, c *Classifier) (*chg.Changelog, error)
+}
+
+type namedImporter struct {
+	name     string
+	importer Importer
+}
+
+// importers in the order they are tried by Detect. The markdown one
+// accepts anything, so it's the last.
+var importers = []namedImporter{
+	{"github", GitHub{}},
+	{"rst", RST{}},
+	{"markdown", Markdown{}},
+}
+
+// Register adds an importer. Registered importers are detected before
+// the built-in ones; registering an existing name replaces it.
+func Register(name string, importer Importer) {
+	for idx, imp := range importers {
+		if imp.name == name {
+			importers[idx].importer = importer
+			return
+		}
+	}
+	importers = append([]namedImporter{{name, importer}}, importers...)
+}
+
+// Get returns the importer registered as name
+func Get(name string) (Importer, error) {
+	for _, imp := range importers {
+		if imp.name == name {
+			return imp.importer, nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown importer: '%s'", name)
+}
+
+// Names lists the registered importers
+func Names() []string {
+	names := make([]string, len(importers))
+	for idx, imp := range importers {
+		names[idx] = imp.name
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Detect returns the name of the first importer that recognizes the
+// content
+func Detect(content []byte) string {
+	for _, imp := range importers {
+		if imp.importer.Detect(content) {
+			return imp.name
+		}
+	}
+	return ""
+}
+
+// Migrate converts the content with the importer named from ("auto"
+// detects it). The result has an Unreleased version and the preamble
+// of new changelogs. Items whose change type was guessed are returned
+// for review.
+func Migrate(content []byte, from string) (*chg.Changelog, []Uncertain, error) {
+	if from == "auto" {
+		if from = Detect(content); from == "" {
+			return nil, nil, fmt.Errorf("Unable to detect the changelog style, inform it with --from")
+		}
+	}
+	importer, err := Get(from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	classifier := &Classifier{}
+	imported, err := importer.Import(content, classifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := chg.NewEmptyChangelog("")
+	c.Versions = imported.Versions
+	c.Links = imported.Links
+	if c.Version("Unreleased") == nil {
+		c.Versions = append([]*chg.Version{{Name: "Unreleased"}}, c.Versions...)
+	}
+	return c, classifier.Uncertain, nil
+}
+
+// Uncertain is an item whose change type was guessed
+type Uncertain struct {
+	Version string
+	Item    string
+	Type    chg.ChangeType
+	Reason  string
+}
+
+func (u Uncertain) String() string {
+	return fmt.Sprintf("%s: '%s' classified as %s (%s)", u.Version, u.Item, u.Type, u.Reason)
+}
+
+// Classifier finds the change type of items by keyword heuristics and
+// keeps the uncertain classifications
+type Classifier struct {
+	Uncertain []Uncertain
+}
+
+var (
+	// rePrefix matches prefixes like "Fix:", "feat(api):" or "[Security]"
+	rePrefix = regexp.MustCompile(`^(?:\[([A-Za-z ]+)\]\s*|([A-Za-z ]+?)(?:\([^)]*\))?!?\s*:\s+)(.+)$`)
+
+	reWord = regexp.MustCompile(`[A-Za-z]+`)
+)
+
+// keywords are the first words of items (or their prefix) that define
+// their change type
+var keywords = map[string][]string{
+	"Added":      {"add", "new", "feat", "feature", "introduce", "support", "implement"},
+	"Changed":    {"change", "update", "improve", "refactor", "bump", "rename", "move", "upgrade", "replace", "perf", "optimize", "breaking", "enhancement", "chore", "docs"},
+	"Deprecated": {"deprecate"},
+	"Fixed":      {"fix", "bugfix", "hotfix", "bug", "correct", "resolve"},
+	"Removed":    {"remove", "delete", "drop"},
+	"Security":   {"security", "cve"},
+}
+
+// hints are words that suggest a change type anywhere in the text
+var hints = map[string][]string{
+	"Deprecated": {"deprecate"},
+	"Fixed":      {"fix", "bug", "crash", "error"},
+	"Security":   {"security", "vulnerability", "cve"},
+}
+
+// sections are common section headings, other than the change types
+// and their aliases
+var sections = map[string][]string{
+	"Added":      {"features", "new features", "enhancements"},
+	"Changed":    {"breaking changes", "changes", "improvements", "other changes", "dependencies", "documentation"},
+	"Deprecated": {"deprecations"},
+	"Fixed":      {"bug fixes", "bugfixes", "fixes"},
+	"Removed":    {"removals"},
+	"Security":   {"security fixes"},
+}
+
+// Section returns the change type of a section heading, or chg.Unknown
+func (c *Classifier) Section(heading string) chg.ChangeType {
+	heading = strings.TrimSpace(heading)
+	if ct := chg.ChangeTypeFromString(heading); ct != chg.Unknown {
+		return ct
+	}
+	return lookup(sections, strings.ToLower(heading))
+}
+
+// Item returns the change type of the item and its text without the
+// type prefix (eg. "Fix: crash" becomes "Crash"). Guessed types are
+// recorded as uncertain.
+func (c *Classifier) Item(version, text string) (chg.ChangeType, string) {
+	if m := rePrefix.FindStringSubmatch(text); m != nil {
+		prefix := m[1] + m[2]
+		if ct := c.Section(prefix); ct != chg.Unknown {
+			return ct, capitalize(m[3])
+		}
+		if ct := keywordType(keywords, prefix); ct != chg.Unknown {
+			return ct, capitalize(m[3])
+		}
+	}
+
+	if first := reWord.FindString(text); first != "" {
+		if ct := keywordType(keywords, first); ct != chg.Unknown {
+			return ct, text
+		}
+	}
+
+	for _, word := range reWord.FindAllString(text, -1) {
+		if ct := keywordType(hints, word); ct != chg.Unknown {
+			return c.uncertain(version, text, ct, fmt.Sprintf("guessed from '%s'", word))
+		}
+	}
+
+	return c.uncertain(version, text, chg.ChangeTypeFromString("Changed"), "no keyword found")
+}
+
+func (c *Classifier) uncertain(version, text string, ct chg.ChangeType, reason string) (chg.ChangeType, string) {
+	if ct == chg.Unknown {
+		// The type isn't configured, so fall back to the first one
+		ct = chg.ChangeTypes()[0]
+	}
+	c.Uncertain = append(c.Uncertain, Uncertain{Version: version, Item: text, Type: ct, Reason: reason})
+	return ct, text
+}
+
+// keywordType returns the change type of word in table, trying its
+// inflections (eg. "fixes", "added" or "removing")
+func keywordType(table map[string][]string, word string) chg.ChangeType {
+	word = strings.ToLower(strings.TrimSpace(word))
+
+	candidates := []string{word}
+	for _, suffix := range []string{"s", "es", "d", "ed", "ing"} {
+		if stem := strings.TrimSuffix(word, suffix); stem != word && stem != "" {
+			candidates = append(candidates, stem)
+			if suffix == "ing" {
+				candidates = append(candidates, stem+"e")
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if ct := lookup(table, candidate); ct != chg.Unknown {
+			return ct
+		}
+	}
+	return chg.Unknown
+}
+
+// lookup returns the change type whose words in table include word
+func lookup(table map[string][]string, word string) chg.ChangeType {
+	for name, words := range table {
+		for _, w := range words {
+			if w == word {
+				return chg.ChangeTypeFromString(name)
+			}
+		}
+	}
+	return chg.Unknown
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// reVersionTitle matches version titles like "v1.2.0 (2020-01-01)",
+// "Version 1.2.0 - January 1, 2020" or "1.2.0, 2020-01-01"
+var reVersionTitle = regexp.MustCompile(`(?i)^(?:version |release )?v?([0-9]+\.[0-9]+(?:\.[0-9]+)?(?:[-+][0-9A-Za-z.\-+]+)?|unreleased)\b\s*[-–—,:]?\s*(?:\((.*)\)|(.*))$`)
+
+// reLinkTitle matches titles with a link, like "[v1.2.0](https://...)"
+var reLinkTitle = regexp.MustCompile(`^\[([^\]]+)\]\(([^)]+)\)\s*(.*)$`)
+
+var reYanked = regexp.MustCompile(`(?i)\[?yanked\]?`)
+
+// parseVersionTitle returns the version of a title, or nil if it isn't
+// a version
+func parseVersionTitle(title string) *chg.Version {
+	title = strings.TrimSpace(title)
+
+	link := ""
+	if m := reLinkTitle.FindStringSubmatch(title); m != nil {
+		title, link = strings.TrimSpace(m[1]+" "+m[3]), m[2]
+	}
+
+	m := reVersionTitle.FindStringSubmatch(title)
+	if m == nil {
+		return nil
+	}
+
+	v := &chg.Version{Name: m[1], Link: link}
+	if strings.EqualFold(v.Name, "unreleased") {
+		v.Name = "Unreleased"
+	}
+
+	date := strings.TrimSpace(m[2] + m[3])
+	if reYanked.MatchString(date) {
+		v.Yanked = true
+		date = strings.TrimSpace(reYanked.ReplaceAllString(date, ""))
+	}
+	switch {
+	case strings.EqualFold(date, "unreleased"):
+		// eg. "1.3.0 (unreleased)"
+		v.Name, v.Link = "Unreleased", ""
+	case date != "":
+		v.Date = chg.NormalizeDate(date)
+	}
+	return v
+}
+
+// builder collects the versions and items of an imported changelog
+type builder struct {
+	classifier *Classifier
+	changelog  *chg.Changelog
+	version    *chg.Version
+	section    chg.ChangeType // Type of the current section, if known
+	skip       bool           // Items of the current section are ignored
+	item       *chg.Item
+}
+
+func newBuilder(c *Classifier) *builder {
+	return &builder{classifier: c, changelog: chg.NewChangelog()}
+}
+
+func (b *builder) startVersion(v *chg.Version) {
+	b.version, b.section, b.skip, b.item = v, chg.Unknown, false, nil
+	b.changelog.Versions = append(b.changelog.Versions, v)
+}
+
+func (b *builder) startSection(heading string) {
+	b.section, b.skip, b.item = b.classifier.Section(heading), false, nil
+}
+
+// addItem adds the item to the current version, classifying it when
+// the section isn't known
+func (b *builder) addItem(text string) {
+	if b.version == nil || b.skip {
+		return
+	}
+
+	ct := b.section
+	if ct == chg.Unknown {
+		ct, text = b.classifier.Item(b.version.Name, text)
+	}
+	b.item = b.version.AddItem(ct, text)
+}
+
+// addChild adds a nested item to the last item
+func (b *builder) addChild(text string) {
+	if b.item == nil {
+		b.addItem(text)
+		return
+	}
+	b.item.Children = append(b.item.Children, &chg.Item{Description: text})
+}
+
+// continueItem appends a continuation line to the last item
+func (b *builder) continueItem(text string) {
+	if b.item == nil || text == "" {
+		return
+	}
+	if n := len(b.item.Children); n > 0 {
+		b.item.Children[n-1].Description += " " + text
+		return
+	}
+	b.item.Description += " " + text
+}
@@ -0,0 +1,219 @@
+package migrate
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/stretchr/testify/assert"
+)
+
+const header = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.0.0/)
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+
+`
+
+func migrateFile(t *testing.T, filename, from string) (string, []Uncertain) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, uncertain, err := Migrate(content, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	c.Render(&buf)
+	return buf.String(), uncertain
+}
+
+func TestClassifierItem(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected chg.ChangeType
+		result   string
+	}{
+		{"Fix: crash on start", chg.Fixed, "Crash on start"},
+		{"feat(api): search endpoint", chg.Added, "Search endpoint"},
+		{"[Security] escape the output", chg.Security, "Escape the output"},
+		{"Bug fixes: wrong exit code", chg.Fixed, "Wrong exit code"},
+		{"Fixes the parser", chg.Fixed, "Fixes the parser"},
+		{"Added `--verbose`", chg.Added, "Added `--verbose`"},
+		{"Removing the old API", chg.Removed, "Removing the old API"},
+		{"Deprecated the v1 endpoints", chg.Deprecated, "Deprecated the v1 endpoints"},
+		{"Bumped yaml to 3.0", chg.Changed, "Bumped yaml to 3.0"},
+		{"Note: this is a note", chg.Changed, "Note: this is a note"},
+	}
+
+	for _, test := range tests {
+		c := &Classifier{}
+		ct, text := c.Item("1.0.0", test.text)
+		assert.Equal(t, test.expected, ct, test.text)
+		assert.Equal(t, test.result, text, test.text)
+	}
+}
+
+func TestClassifierItemUncertain(t *testing.T) {
+	c := &Classifier{}
+
+	ct, _ := c.Item("1.0.0", "Errors are clearer")
+	assert.Equal(t, chg.Fixed, ct)
+	ct, _ = c.Item("1.0.0", "Handle empty input")
+	assert.Equal(t, chg.Changed, ct)
+
+	expected := []Uncertain{
+		{Version: "1.0.0", Item: "Errors are clearer", Type: chg.Fixed, Reason: "guessed from 'Errors'"},
+		{Version: "1.0.0", Item: "Handle empty input", Type: chg.Changed, Reason: "no keyword found"},
+	}
+	assert.Equal(t, expected, c.Uncertain)
+	assert.Equal(t, "1.0.0: 'Handle empty input' classified as Changed (no keyword found)", c.Uncertain[1].String())
+}
+
+func TestClassifierSection(t *testing.T) {
+	c := &Classifier{}
+	assert.Equal(t, chg.Fixed, c.Section("Bug Fixes"))
+	assert.Equal(t, chg.Added, c.Section("Features"))
+	assert.Equal(t, chg.Removed, c.Section("removed"))
+	assert.Equal(t, chg.Unknown, c.Section("What's Changed"))
+}
+
+func TestParseVersionTitle(t *testing.T) {
+	tests := map[string]*chg.Version{
+		"v1.2.0 (2020-01-01)":                  {Name: "1.2.0", Date: "2020-01-01"},
+		"Version 1.2.0 - January 3, 2020":      {Name: "1.2.0", Date: "2020-01-03"},
+		"1.2.0-rc.1, 2020/01/02":               {Name: "1.2.0-rc.1", Date: "2020-01-02"},
+		"1.0 [YANKED]":                         {Name: "1.0", Yanked: true},
+		"1.3.0 (unreleased)":                   {Name: "Unreleased"},
+		"[v2.0.0](https://example.com/v2.0.0)": {Name: "2.0.0", Link: "https://example.com/v2.0.0"},
+		"Unreleased":                           {Name: "Unreleased"},
+		"What's Changed":                       nil,
+		"Changes in 1.0":                       nil,
+	}
+
+	for title, expected := range tests {
+		assert.Equal(t, expected, parseVersionTitle(title), title)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := map[string]string{
+		"testdata/markdown.md": "markdown",
+		"testdata/github.md":   "github",
+		"testdata/CHANGES.rst": "rst",
+	}
+
+	for filename, expected := range tests {
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expected, Detect(content), filename)
+	}
+
+	assert.Equal(t, "", Detect([]byte("Nothing here")))
+}
+
+func TestMigrateMarkdown(t *testing.T) {
+	expected := header + "## Unreleased\n\n" +
+		`## 1.2.0 - 2020-01-01
+### Added
+- Added ` + "`--verbose`" + ` option
+
+### Changed
+- Improve the docs of the parser
+
+### Fixed
+- Crash when the file is empty
+- Error messages are clearer
+
+## 1.1.0 - 2019-03-03
+### Added
+- Support YAML
+
+### Fixed
+- Wrong exit code
+    - on Windows
+`
+
+	out, uncertain := migrateFile(t, "testdata/markdown.md", "auto")
+	assert.Equal(t, expected, out)
+	assert.Len(t, uncertain, 1)
+}
+
+func TestMigrateGitHub(t *testing.T) {
+	expected := header + "## Unreleased\n\n" +
+		`## [2.0.0]
+### Added
+- Add search command by @alice in [#12](https://github.com/o/r/pull/12)
+
+### Changed
+- Bump yaml to 3.0 by @dependabot in [#13](https://github.com/o/r/pull/13)
+- Handle empty input by @bob in [#14](https://github.com/o/r/pull/14)
+
+[2.0.0]: https://github.com/o/r/compare/v1.0.0...v2.0.0
+`
+
+	out, uncertain := migrateFile(t, "testdata/github.md", "github")
+	assert.Equal(t, expected, out)
+	assert.Len(t, uncertain, 1)
+}
+
+func TestMigrateRST(t *testing.T) {
+	expected := header +
+		`## Unreleased
+### Removed
+- Drop Python 2 support.
+
+## 1.2.0 - 2020-01-01
+### Deprecated
+- Deprecate the ` + "`old`" + ` module.
+
+### Fixed
+- Fix ` + "`parse()`" + ` with empty strings, see #42.
+- Handle [unicode](https://unicode.org) names in the header.
+`
+
+	out, uncertain := migrateFile(t, "testdata/CHANGES.rst", "auto")
+	assert.Equal(t, expected, out)
+	assert.Empty(t, uncertain)
+}
+
+func TestMigrateErrors(t *testing.T) {
+	_, _, err := Migrate([]byte("Nothing here"), "auto")
+	assert.EqualError(t, err, "Unable to detect the changelog style, inform it with --from")
+
+	_, _, err = Migrate([]byte("## 1.0.0"), "unknown")
+	assert.EqualError(t, err, "Unknown importer: 'unknown'")
+}
+
+type fakeImporter struct{}
+
+func (fakeImporter) Detect(content []byte) bool {
+	return bytes.HasPrefix(content, []byte("fake"))
+}
+
+func (fakeImporter) Import(content []byte, c *Classifier) (*chg.Changelog, error) {
+	v := &chg.Version{Name: "1.0.0", Date: "2020-01-01"}
+	v.AddItem(chg.Added, string(content))
+	return &chg.Changelog{Versions: []*chg.Version{v}}, nil
+}
+
+func TestRegister(t *testing.T) {
+	saved := importers
+	defer func() { importers = saved }()
+
+	Register("fake", fakeImporter{})
+	assert.Equal(t, []string{"fake", "github", "markdown", "rst"}, Names())
+	assert.Equal(t, "fake", Detect([]byte("fake ## 1.0.0")))
+
+	c, _, err := Migrate([]byte("fake"), "auto")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Unreleased", "1.0.0"}, []string{c.Versions[0].Name, c.Versions[1].Name})
+}
@@ -0,0 +1,124 @@
+package migrate
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// RST imports reStructuredText changelogs, like the CHANGES.rst of
+// Python projects, with underlined version titles
+type RST struct{}
+
+var (
+	reRSTItem = regexp.MustCompile(`^(\s*)[*+-]\s+(.*)$`)
+
+	reRSTCode  = regexp.MustCompile("``([^`]+)``")
+	reRSTLink  = regexp.MustCompile("`([^`<]+?)\\s*<([^>]+)>`__?")
+	reRSTRole  = regexp.MustCompile(":([a-z]+):`([^`]+)`")
+	reRSTIssue = regexp.MustCompile(`^(?:issue|pr|pull|gh)$`)
+)
+
+// Detect accepts content with underlined titles and no markdown
+// headings
+func (RST) Detect(content []byte) bool {
+	all := lines(content)
+	underlined := false
+	for idx, line := range all {
+		if reATXHeading.MatchString(line) {
+			return false
+		}
+		if isTitle(all, idx) {
+			underlined = true
+		}
+	}
+	return underlined
+}
+
+// isUnderline reports whether the line repeats one of the title
+// adornment characters, eg. "-----"
+func isUnderline(line string) bool {
+	if len(line) < 3 || !strings.ContainsRune("=-~^*+#\"'`:.", rune(line[0])) {
+		return false
+	}
+	return strings.Count(line, line[:1]) == len(line)
+}
+
+// isTitle reports whether the line at idx is a title, followed by an
+// underline at least as long
+func isTitle(all []string, idx int) bool {
+	if idx+1 >= len(all) {
+		return false
+	}
+	text := strings.TrimSpace(all[idx])
+	return text != "" && !isUnderline(text) &&
+		isUnderline(all[idx+1]) && len(all[idx+1]) >= len(text)
+}
+
+// Import converts the versions and items of the content. Titles that
+// are versions start a version; the others are sections.
+func (RST) Import(content []byte, c *Classifier) (*chg.Changelog, error) {
+	b := newBuilder(c)
+	all := lines(content)
+	blank := false
+
+	for idx := 0; idx < len(all); idx++ {
+		line := all[idx]
+
+		if strings.TrimSpace(line) == "" {
+			blank = true
+			continue
+		}
+
+		if isUnderline(line) {
+			// Overline of a title, or a transition
+			continue
+		}
+
+		if isTitle(all, idx) {
+			if v := parseVersionTitle(line); v != nil {
+				b.startVersion(v)
+			} else {
+				b.startSection(line)
+			}
+			idx++
+			blank = false
+			continue
+		}
+
+		if m := reRSTItem.FindStringSubmatch(line); m != nil {
+			if len(m[1]) >= 2 && b.item != nil {
+				b.addChild(rstInline(m[2]))
+			} else {
+				b.addItem(rstInline(m[2]))
+			}
+			blank = false
+			continue
+		}
+
+		// Items continue in indented lines; other text isn't migrated
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			b.continueItem(rstInline(strings.TrimSpace(line)))
+		} else if blank {
+			b.item = nil
+		}
+		blank = false
+	}
+
+	return b.changelog, nil
+}
+
+// rstInline converts the inline markup to markdown: “code“, `text
+// <url>`_ links and roles like :issue:`12`
+func rstInline(text string) string {
+	text = reRSTCode.ReplaceAllString(text, "`$1`")
+	text = reRSTLink.ReplaceAllString(text, "[$1]($2)")
+	return reRSTRole.ReplaceAllStringFunc(text, func(role string) string {
+		m := reRSTRole.FindStringSubmatch(role)
+		if reRSTIssue.MatchString(m[1]) {
+			return "#" + strings.TrimPrefix(m[2], "#")
+		}
+		return "`" + m[2] + "`"
+	})
+}
@@ -13,25 +13,216 @@ import (
 	blackfriday "github.com/russross/blackfriday/v2"
 )
 
-// Parse input into a proper Changelog struct
+// Parse input into a proper Changelog struct. It exits the process
+// when reading or parsing fails; use Read to handle the error.
 func Parse(r io.Reader) *chg.Changelog {
-	extensions := blackfriday.NoIntraEmphasis | blackfriday.Strikethrough
-	renderer := newRenderer()
-
-	input, err := ioutil.ReadAll(r)
+	changelog, err := Read(r)
 	if err != nil {
 		log.Fatal(err)
 		return nil
 	}
-	blackfriday.Run(input, blackfriday.WithExtensions(extensions), blackfriday.WithRenderer(&renderer))
+	return changelog
+}
+
+// Read parses the changelog read from r. It fails on the first syntax
+// error, a *SyntaxError.
+func Read(r io.Reader) (*chg.Changelog, error) {
+	input, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	changelog, m := parse(input)
+	if len(m.Errors) > 0 {
+		return nil, m.Errors[0]
+	}
+	return changelog, nil
+}
+
+// ParseBytes parses the changelog in input. Content with syntax errors
+// is ignored; use Read to handle them.
+func ParseBytes(input []byte) *chg.Changelog {
+	changelog, _ := parse(input)
+	return changelog
+}
+
+// parse builds the changelog and the source map of input
+func parse(input []byte) (*chg.Changelog, *SourceMap) {
+	extensions := blackfriday.NoIntraEmphasis | blackfriday.Strikethrough | blackfriday.FencedCode
+
+	m := newSourceMap()
+	renderer := newRenderer(m)
+
+	// blackfriday doesn't take "\r\n" lines as blank
+	input = bytes.Replace(input, []byte("\r\n"), []byte("\n"), -1)
+	input, links := extractLinkDefs(markLines(input, m), m)
+	blackfriday.Run(escapeFences(normalizeIndent(input)), blackfriday.WithExtensions(extensions), blackfriday.WithRenderer(&renderer))
+
+	changelog := renderer.Result()
+	changelog.Title, changelog.Preamble = splitHeader([]byte(removeMarkers(string(input))))
+	addLinkDefs(changelog, links)
+	return changelog, m
+}
+
+var (
+	reFence       = regexp.MustCompile("^[ \t]*(```|~~~)")
+	reHeading     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	reSetextTitle = regexp.MustCompile(`^ {0,3}=+[ \t]*$`)
+)
+
+// splitHeader returns the raw markdown of the title, the first level 1
+// heading, and of the preamble, the content before the first version.
+// Keeping the preamble as is preserves lists, code blocks, images and
+// any other markdown in it.
+func splitHeader(input []byte) (title, preamble string) {
+	var (
+		body       []string
+		foundTitle = false
+		inFence    = false
+	)
+
+	for _, line := range strings.Split(string(input), "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		switch {
+		case reFence.MatchString(line):
+			inFence = !inFence
+		case inFence:
+		case reHeading.MatchString(line):
+			matches := reHeading.FindStringSubmatch(line)
+			if len(matches[1]) == 2 {
+				return title, trimBlankLines(strings.Join(body, "\n"))
+			}
+			if len(matches[1]) == 1 && !foundTitle {
+				title, foundTitle = strings.TrimSpace(matches[2]), true
+				continue
+			}
+		case reSetextTitle.MatchString(line) && !foundTitle && len(body) > 0 &&
+			trimBlankLines(strings.Join(body, "\n")) == strings.TrimSpace(body[len(body)-1]):
+			// "Title\n=====" as the first content
+			title, foundTitle = strings.TrimSpace(body[len(body)-1]), true
+			body = nil
+			continue
+		}
+		body = append(body, line)
+	}
+
+	return title, trimBlankLines(strings.Join(body, "\n"))
+}
 
-	return renderer.Result()
+// trimBlankLines removes the leading blank lines and the trailing
+// whitespace, keeping the indentation of the first line
+func trimBlankLines(s string) string {
+	s = strings.TrimRight(s, " \t\r\n")
+	for {
+		idx := strings.Index(s, "\n")
+		if idx < 0 || strings.TrimSpace(s[:idx]) != "" {
+			break
+		}
+		s = s[idx+1:]
+	}
+	if strings.TrimSpace(s) == "" {
+		return ""
+	}
+	return s
 }
 
-func newRenderer() renderer {
+var reLinkDefLine = regexp.MustCompile(`^ {0,3}\[([^\]^][^\]]*)\]:[ \t]*<?([^\s>]+)>?(?:[ \t]+(?:"([^"]*)"|'([^']*)'|\(([^)]*)\)))?[ \t]*\r?$`)
+
+// extractLinkDefs removes the link reference definitions from the input
+// and returns them, recording their lines in m. Without the definitions,
+// blackfriday keeps the reference-style links as text, which preserves
+// them when rendering.
+func extractLinkDefs(input []byte, m *SourceMap) ([]byte, []*chg.LinkDef) {
+	var (
+		buf   bytes.Buffer
+		links []*chg.LinkDef
+		fence string
+	)
+
+	for lineno, line := range strings.SplitAfter(string(input), "\n") {
+		trimmed := strings.TrimSuffix(line, "\n")
+		if matches := reFenceLine.FindStringSubmatch(trimmed); matches != nil {
+			if fence == "" {
+				fence = matches[2]
+			} else if matches[3] == "" && matches[2][0] == fence[0] && len(matches[2]) >= len(fence) {
+				fence = ""
+			}
+		} else if matches := reLinkDefLine.FindStringSubmatch(trimmed); matches != nil && fence == "" {
+			links = append(links, &chg.LinkDef{
+				Label: matches[1],
+				URL:   matches[2],
+				Title: matches[3] + matches[4] + matches[5],
+			})
+			if _, ok := m.Links[normalizeLabel(matches[1])]; !ok {
+				m.Links[normalizeLabel(matches[1])] = lineno
+			}
+			continue
+		}
+		buf.WriteString(line)
+	}
+
+	return buf.Bytes(), links
+}
+
+// addLinkDefs sets the links of the versions from their definitions and
+// keeps the other ones in the changelog. Like in markdown, the first
+// definition of a label wins.
+func addLinkDefs(c *chg.Changelog, links []*chg.LinkDef) {
+	seen := make(map[string]bool)
+	for _, l := range links {
+		label := normalizeLabel(l.Label)
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+
+		if v := c.Version(label); v != nil {
+			if v.Link == "" {
+				v.Link = l.URL
+			}
+			continue
+		}
+		c.Links = append(c.Links, l)
+	}
+}
+
+// normalizeLabel returns the label of a link in lowercase and with its
+// whitespace collapsed, which is how labels are matched
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
+
+// ParseItem parses the markdown text of a single item, like the one
+// written in an editor: the first paragraph is the description and the
+// following blocks are the body and the children
+func ParseItem(text string) *chg.Item {
+	ct := chg.ChangeTypes()[0]
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "## Unreleased\n### %s\n- %s\n", ct.String(), lines[0])
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			buf.WriteString("\n")
+		} else {
+			buf.WriteString("    " + line + "\n")
+		}
+	}
+
+	change := ParseBytes(buf.Bytes()).Versions[0].Change(ct)
+	if change == nil || len(change.Items) == 0 {
+		return &chg.Item{Description: strings.TrimSpace(text)}
+	}
+	return change.Items[0]
+}
+
+func newRenderer(m *SourceMap) renderer {
 	r := renderer{}
 	r.changelog = chg.NewChangelog()
-	r.reVersion = regexp.MustCompile(`(?i)\[?(?P<name>[0-9a-zA-Z\-\.]+)\]?(?: - (?P<date>[0-9a-z\-\.]+))?(?P<yanked> \[YANKED\])?`)
+	r.reVersion = regexp.MustCompile(`(?i)^\[?(?P<name>[0-9a-zA-Z\-\.]+)\]?(?:\((?P<link>[^()\s]+)\))?(?: - (?P<date>[0-9a-z\-\./,:+ ]+))?(?P<yanked>\s*\[YANKED\])?(?P<superseded>\s*\[SUPERSEDED\])?\s*$`)
+	r.sourceMap = m
+	r.lastLine = -1
 	return r
 }
 
@@ -39,11 +230,14 @@ type renderer struct {
 	blackfriday.Renderer
 
 	changelog      *chg.Changelog
-	captureContent bool            // should we capture content in a special buffer?
-	captureBuffer  bytes.Buffer    // buffer where we will store content temporarily
 	reVersion      *regexp.Regexp  // matches the version line
 	currentVersion *chg.Version    // current version being parsed
 	currentChange  *chg.ChangeList // current changelist being parsed
+
+	sourceMap *SourceMap
+	spans     map[*blackfriday.Node]Span // lines of the nodes, from readMarkers
+	lastLine  int                        // last line of the nodes rendered
+	inUnknown bool                       // inside a section with unknown change type
 }
 
 func (r *renderer) Result() *chg.Changelog {
@@ -51,45 +245,49 @@ func (r *renderer) Result() *chg.Changelog {
 }
 
 // RenderHeader is called at the beginning of the parsing
-func (r *renderer) RenderHeader(w io.Writer, ast *blackfriday.Node) {}
+func (r *renderer) RenderHeader(w io.Writer, ast *blackfriday.Node) {
+	liftHeadings(ast)
+	r.spans = readMarkers(ast)
+}
 
-// RenderHeader is called at the end of the parsing
+// RenderFooter is called at the end of the parsing
 func (r *renderer) RenderFooter(w io.Writer, ast *blackfriday.Node) {
-	// if the changelog only has the first paragraph (without versions)
-	// flush it to the
-	if content := r.endCapture(); content != "" {
-		r.changelog.Preamble = content
-	}
+	r.closeVersion()
 }
 
 // RenderNode is called for every node on the AST tree
 func (r *renderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
-	var writer io.Writer
-	if r.captureContent {
-		writer = &r.captureBuffer
-	} else {
-		writer = w
+	switch node.Type {
+	case blackfriday.Document, blackfriday.List, blackfriday.Item, blackfriday.Heading:
+		// Headings and items record their lines themselves, and the
+		// lines of the document and lists are the ones of their items
+	default:
+		if entering {
+			r.seen(node)
+		}
 	}
 
 	switch node.Type {
 	case blackfriday.Code:
-		return r.Code(writer, node, entering)
+		return r.Code(w, node, entering)
 	case blackfriday.Del:
-		return r.Del(writer, node, entering)
+		return r.Del(w, node, entering)
 	case blackfriday.Emph:
-		return r.Emph(writer, node, entering)
+		return r.Emph(w, node, entering)
 	case blackfriday.Heading:
-		return r.Heading(writer, node, entering)
+		return r.Heading(w, node, entering)
+	case blackfriday.HTMLSpan:
+		return r.HTMLSpan(w, node, entering)
 	case blackfriday.Item:
-		return r.ListItem(writer, node, entering)
+		return r.ListItem(w, node, entering)
 	case blackfriday.Link:
-		return r.Link(writer, node, entering)
+		return r.Link(w, node, entering)
 	case blackfriday.Paragraph:
-		return r.Paragraph(writer, node, entering)
+		return r.Paragraph(w, node, entering)
 	case blackfriday.Strong:
-		return r.Strong(writer, node, entering)
+		return r.Strong(w, node, entering)
 	case blackfriday.Text:
-		return r.Text(writer, node, entering)
+		return r.Text(w, node, entering)
 	}
 	return blackfriday.GoToNext
 }
@@ -114,35 +312,66 @@ func (r *renderer) Emph(w io.Writer, node *blackfriday.Node, entering bool) blac
 	return blackfriday.GoToNext
 }
 
+// HTMLSpan keeps inline HTML as is
+func (r *renderer) HTMLSpan(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	w.Write(node.Literal)
+	return blackfriday.GoToNext
+}
+
 // Heading is called for each Heading (1 to 6) node found
 func (r *renderer) Heading(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
 	level := node.HeadingData.Level
+	span, hasSpan := r.spans[node]
+
 	switch level {
-	case 1: // Document title
-		r.startCapture()
-		// We don't care about changelog title
+	case 1: // Document title, read by splitHeader
+		if hasSpan && r.sourceMap.Title < 0 {
+			r.sourceMap.Title = span.Start
+		}
+		r.seen(node)
 		return blackfriday.SkipChildren
 	case 2: // It's a version
-		if content := r.endCapture(); content != "" {
-			r.changelog.Preamble = content
-		}
-
-		r.currentChange = nil
-		r.currentVersion = &chg.Version{}
+		r.closeVersion()
+		r.inUnknown = false
+		r.seen(node)
 
 		var buf bytes.Buffer
 		r.renderInline(&buf, node, entering)
-		metadata := r.parseVersionLine(buf.String())
+		metadata, ok := r.parseVersionLine(buf.String())
+		if !ok {
+			r.sourceMap.Errors = append(r.sourceMap.Errors, &SyntaxError{
+				Line:    span.Start,
+				Message: fmt.Sprintf("invalid version heading '%s'", strings.TrimSpace(buf.String())),
+			})
+			return blackfriday.SkipChildren
+		}
 
+		r.currentVersion = &chg.Version{}
 		r.currentVersion.Name = metadata["name"]
-		r.currentVersion.Date = metadata["date"]
+		r.currentVersion.Date = strings.TrimSpace(metadata["date"])
+		r.currentVersion.Link = metadata["link"]
 		if metadata["yanked"] != "" {
 			r.currentVersion.Yanked = true
 		}
+		if metadata["superseded"] != "" {
+			r.currentVersion.Superseded = true
+		}
 		r.changelog.Versions = append(r.changelog.Versions, r.currentVersion)
+		if hasSpan {
+			r.sourceMap.Versions[r.currentVersion] = Span{Start: span.Start}
+		}
 
 		return blackfriday.SkipChildren
 	case 3, 4: // It's a change
+		if r.currentVersion == nil {
+			// Headings of the preamble
+			r.seen(node)
+			return blackfriday.SkipChildren
+		}
+		r.closeChange()
+		r.inUnknown = false
+		r.seen(node)
+
 		var buf bytes.Buffer
 		r.renderInline(&buf, node, entering)
 		changeName := buf.String()
@@ -154,15 +383,60 @@ func (r *renderer) Heading(w io.Writer, node *blackfriday.Node, entering bool) b
 				r.currentChange = chg.NewChangeList(changeName)
 				r.currentVersion.Changes = append(r.currentVersion.Changes, r.currentChange)
 			}
+			if hasSpan {
+				m := r.sourceMap
+				m.Changes[r.currentChange] = append(m.Changes[r.currentChange], Span{Start: span.Start})
+				m.Headings[r.currentChange] = append(m.Headings[r.currentChange], span.Start)
+			}
 		} else {
-			r.currentChange = nil
+			r.inUnknown = true
+			r.warn(node, fmt.Sprintf("unknown change type '%s', its items are ignored", strings.TrimSpace(changeName)))
 		}
 
 		return blackfriday.SkipChildren
 	}
+	r.seen(node)
 	return blackfriday.GoToNext
 }
 
+// seen records that the lines of node were rendered
+func (r *renderer) seen(node *blackfriday.Node) {
+	if span, ok := r.spans[node]; ok && span.End > r.lastLine {
+		r.lastLine = span.End
+	}
+}
+
+func (r *renderer) warn(node *blackfriday.Node, message string) {
+	if span, ok := r.spans[node]; ok {
+		r.sourceMap.Warnings = append(r.sourceMap.Warnings, Warning{Line: span.Start, Message: message})
+	}
+}
+
+// closeChange ends the span of the current change at the last line
+// rendered
+func (r *renderer) closeChange() {
+	if r.currentChange == nil {
+		return
+	}
+	if spans := r.sourceMap.Changes[r.currentChange]; len(spans) > 0 {
+		spans[len(spans)-1].End = r.sourceMap.extend(r.lastLine)
+	}
+	r.currentChange = nil
+}
+
+// closeVersion ends the span of the current version and its change
+func (r *renderer) closeVersion() {
+	r.closeChange()
+	if r.currentVersion == nil {
+		return
+	}
+	if span, ok := r.sourceMap.Versions[r.currentVersion]; ok {
+		span.End = r.sourceMap.extend(r.lastLine)
+		r.sourceMap.Versions[r.currentVersion] = span
+	}
+	r.currentVersion = nil
+}
+
 // Link deals with hyperlinks (both versions and in text)
 func (r *renderer) Link(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
 	if entering {
@@ -182,16 +456,331 @@ func (r *renderer) Link(w io.Writer, node *blackfriday.Node, entering bool) blac
 
 // ListItem is called for each item
 func (r *renderer) ListItem(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
-	if r.currentChange != nil {
-		var buf bytes.Buffer
-		r.renderInline(&buf, node, entering)
-		item := &chg.Item{Description: buf.String()}
+	switch {
+	case r.currentChange != nil:
+		item := r.parseItem(node)
 		r.currentChange.Items = append(r.currentChange.Items, item)
+		if span, ok := r.spans[node]; ok {
+			r.sourceMap.Items[item] = Span{Start: span.Start, End: r.sourceMap.extend(span.End)}
+		}
+	case r.currentVersion != nil && !r.inUnknown:
+		r.warn(node, "item outside of a change section is ignored")
 	}
+	r.seen(node)
 
 	return blackfriday.SkipChildren
 }
 
+// parseItem builds the item from the blocks of the list item: the first
+// paragraph is the description, nested lists are the children and the
+// other blocks are the body
+func (r *renderer) parseItem(node *blackfriday.Node) *chg.Item {
+	item := &chg.Item{}
+
+	for n := node.FirstChild; n != nil; n = n.Next {
+		switch n.Type {
+		case blackfriday.List:
+			for child := n.FirstChild; child != nil; child = child.Next {
+				item.Children = append(item.Children, r.parseItem(child))
+			}
+		case blackfriday.Paragraph:
+			text := r.blockMarkdown(n)
+			if item.Description == "" && len(item.Body) == 0 {
+				item.Description = text
+			} else if text != "" {
+				item.Body = append(item.Body, text)
+			}
+		default:
+			if text := r.blockMarkdown(n); text != "" {
+				item.Body = append(item.Body, text)
+			}
+		}
+	}
+
+	return item
+}
+
+// blockMarkdown renders a block of an item back to markdown
+func (r *renderer) blockMarkdown(node *blackfriday.Node) string {
+	switch node.Type {
+	case blackfriday.CodeBlock:
+		return codeBlock(node)
+	case blackfriday.HTMLBlock:
+		return strings.TrimRight(string(node.Literal), "\n")
+	case blackfriday.HorizontalRule:
+		return "---"
+	case blackfriday.BlockQuote:
+		var blocks []string
+		for n := node.FirstChild; n != nil; n = n.Next {
+			blocks = append(blocks, r.blockMarkdown(n))
+		}
+		return prefixLines(strings.Join(blocks, "\n\n"), "> ", ">")
+	case blackfriday.List:
+		var items []string
+		for n := node.FirstChild; n != nil; n = n.Next {
+			text := prefixLines(r.parseItem(n).Text(), "    ", "")
+			items = append(items, "- "+strings.TrimPrefix(text, "    "))
+		}
+		return strings.Join(items, "\n")
+	}
+
+	var buf bytes.Buffer
+	r.renderInline(&buf, node, true)
+	text := strings.TrimSpace(buf.String())
+	if node.Type == blackfriday.Heading && text != "" {
+		text = strings.Repeat("#", node.HeadingData.Level) + " " + text
+	}
+	return text
+}
+
+// prefixLines adds prefix to each line of text, or blank to the empty ones
+func prefixLines(text, prefix, blank string) string {
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[idx] = blank
+		} else {
+			lines[idx] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// liftHeadings moves the headings inside of lists, and what follows
+// them, after the lists. blackfriday keeps a heading right after an item
+// in it, but like at the top level it starts a new section.
+func liftHeadings(doc *blackfriday.Node) {
+	for list := doc.FirstChild; list != nil; list = list.Next {
+		if list.Type != blackfriday.List {
+			continue
+		}
+		heading := findListHeading(list)
+		if heading == nil {
+			continue
+		}
+
+		// From the heading up to the list, everything after each node
+		// moves: blocks as they are and items in a copy of their list
+		moved := []*blackfriday.Node{heading}
+		for n := heading; n != list; n = n.Parent {
+			var rest []*blackfriday.Node
+			for next := n.Next; next != nil; next = next.Next {
+				rest = append(rest, next)
+			}
+			if len(rest) == 0 {
+				continue
+			}
+
+			if n.Parent.Type != blackfriday.List {
+				moved = append(moved, rest...)
+				continue
+			}
+			newList := blackfriday.NewNode(blackfriday.List)
+			newList.ListData = n.Parent.ListData
+			for _, item := range rest {
+				item.Unlink()
+				newList.AppendChild(item)
+			}
+			moved = append(moved, newList)
+		}
+
+		next := list.Next
+		for _, n := range moved {
+			n.Unlink()
+			if next != nil {
+				next.InsertBefore(n)
+			} else {
+				doc.AppendChild(n)
+			}
+		}
+	}
+}
+
+// findListHeading returns the first heading in the items of the list,
+// or of their nested lists
+func findListHeading(list *blackfriday.Node) *blackfriday.Node {
+	for item := list.FirstChild; item != nil; item = item.Next {
+		for n := item.FirstChild; n != nil; n = n.Next {
+			if n.Type == blackfriday.Heading {
+				return n
+			}
+			if n.Type == blackfriday.List {
+				if heading := findListHeading(n); heading != nil {
+					return heading
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Markers used by escapeFences to keep the content of fenced code
+// blocks inside list items
+const (
+	fenceInfoMarker  = "\x1efence-info:"
+	fenceBlankMarker = "\x1efence-blank"
+)
+
+var reFenceLine = regexp.MustCompile("^([ \t]*)(`{3,}|~{3,})[ \t]*([^`\r]*?)[ \t]*\r?$")
+
+// escapeFences works around blackfriday losing content of fenced code
+// blocks inside list items: fences with an info string (eg. "```bash")
+// aren't recognized, which swallows the rest of the list, and blank
+// lines are dropped. For indented fences, the info is moved to a marker
+// line inside the code and blank lines are replaced by markers, which
+// codeBlock restores.
+func escapeFences(input []byte) []byte {
+	var (
+		buf    bytes.Buffer
+		fence  string
+		indent string
+	)
+
+	for _, line := range strings.SplitAfter(string(input), "\n") {
+		matches := reFenceLine.FindStringSubmatch(strings.TrimSuffix(line, "\n"))
+		switch {
+		case fence != "" && matches != nil && matches[3] == "" &&
+			matches[2][0] == fence[0] && len(matches[2]) >= len(fence):
+			fence = ""
+		case fence != "" && indent != "" && strings.TrimSpace(line) == "":
+			buf.WriteString(indent + fenceBlankMarker + "\n")
+			continue
+		case fence == "" && matches != nil:
+			fence, indent = matches[2], matches[1]
+			if info := matches[3]; indent != "" && info != "" {
+				fmt.Fprintf(&buf, "%s%s\n%s%s%s\n", indent, fence, indent, fenceInfoMarker, info)
+				continue
+			}
+		}
+		buf.WriteString(line)
+	}
+
+	return buf.Bytes()
+}
+
+var (
+	reListMarker = regexp.MustCompile(`^( *)([-*+]|[0-9]{1,9}[.)])( +|$)`)
+	reBreak      = regexp.MustCompile(`^ {0,3}(?:(?:- *){3,}|(?:\* *){3,}|(?:_ *){3,})$`)
+	reBlockStart = regexp.MustCompile("^ {0,3}(?:#|>|```|~~~)")
+)
+
+// normalizeIndent indents the content of list items the way blackfriday
+// expects, 4 spaces per level. Without it, the content indented to the
+// item text, like the 2 spaces of "- ", ends the list: paragraphs and
+// code blocks are lost and nested items become siblings. Lines already
+// indented enough are kept, so the input is only changed where
+// blackfriday would misread it.
+func normalizeIndent(input []byte) []byte {
+	type listItem struct {
+		content int // Column of the item text in the input
+		target  int // Column blackfriday expects its content at
+	}
+
+	var (
+		buf        bytes.Buffer
+		items      []listItem
+		fence      string
+		fenceShift int
+		prevBlank  = true
+	)
+
+	for _, line := range strings.SplitAfter(string(input), "\n") {
+		body := strings.TrimRight(line, "\r\n")
+		fenceMatch := reFenceLine.FindStringSubmatch(body)
+
+		if fence != "" {
+			if fenceMatch != nil && fenceMatch[3] == "" && fenceMatch[2][0] == fence[0] && len(fenceMatch[2]) >= len(fence) {
+				fence = ""
+			}
+			if strings.TrimSpace(body) != "" {
+				line = strings.Repeat(" ", fenceShift) + line
+			}
+			buf.WriteString(line)
+			continue
+		}
+
+		text := strings.TrimLeft(body, " ")
+		if text == "" || strings.HasPrefix(text, "\t") {
+			prevBlank = text == ""
+			buf.WriteString(line)
+			continue
+		}
+		indent := len(body) - len(text)
+
+		marker := reListMarker.FindStringSubmatch(body)
+		if reBreak.MatchString(body) {
+			marker = nil
+		}
+		lazy := !prevBlank && marker == nil && !reBlockStart.MatchString(text)
+		prevBlank = false
+
+		if !lazy {
+			for len(items) > 0 && indent < items[len(items)-1].content {
+				items = items[:len(items)-1]
+			}
+		}
+
+		switch {
+		case marker != nil:
+			width := len(marker[2]) + len(marker[3])
+			if len(marker[3]) == 0 || len(marker[3]) > 4 {
+				// Empty items and indented code start a space after the marker
+				width = len(marker[2]) + 1
+			}
+			depth := len(items)
+			items = append(items, listItem{content: indent + width, target: 4 * (depth + 1)})
+			line = strings.Repeat(" ", 4*depth) + line[indent:]
+		case len(items) > 0:
+			item := items[len(items)-1]
+			shifted := indent
+			switch {
+			case indent >= item.target+4:
+			case !lazy && indent-item.content >= 4:
+				// Indented code, relative to the item text
+				shifted = item.target + indent - item.content
+			case indent < item.target:
+				shifted = item.target
+			}
+			if shifted < indent {
+				shifted = indent
+			}
+			line = strings.Repeat(" ", shifted) + line[indent:]
+
+			if fenceMatch != nil {
+				fence, fenceShift = fenceMatch[2], shifted-indent
+			}
+		default:
+			if fenceMatch != nil {
+				fence, fenceShift = fenceMatch[2], 0
+			}
+		}
+		buf.WriteString(line)
+	}
+
+	return buf.Bytes()
+}
+
+// codeBlock renders the code block as a fenced one
+func codeBlock(node *blackfriday.Node) string {
+	code := string(node.Literal)
+	if !strings.HasSuffix(code, "\n") {
+		code += "\n"
+	}
+
+	info := string(node.CodeBlockData.Info)
+	if strings.HasPrefix(code, fenceInfoMarker) {
+		idx := strings.Index(code, "\n")
+		info, code = code[len(fenceInfoMarker):idx], code[idx+1:]
+	}
+	code = strings.Replace(code, fenceBlankMarker, "", -1)
+
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fmt.Sprintf("%s%s\n%s%s", fence, info, code, fence)
+}
+
 // Paragraph handles... paragraphs
 func (r *renderer) Paragraph(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
 	// Item will handle it's own spacing stuff
@@ -216,21 +805,13 @@ func (r *renderer) Text(w io.Writer, node *blackfriday.Node, entering bool) blac
 	return blackfriday.GoToNext
 }
 
-func (r *renderer) startCapture() {
-	r.captureContent = true
-}
-
-func (r *renderer) endCapture() string {
-	if r.captureContent {
-		r.captureContent = false
-		return strings.TrimSpace(r.captureBuffer.String())
+// parseVersionLine returns the parts of the version heading, or false
+// when it doesn't have a version
+func (r *renderer) parseVersionLine(line string) (map[string]string, bool) {
+	matches := r.reVersion.FindStringSubmatch(strings.TrimSpace(line))
+	if matches == nil {
+		return nil, false
 	}
-
-	return ""
-}
-
-func (r *renderer) parseVersionLine(line string) map[string]string {
-	matches := r.reVersion.FindStringSubmatch(line)
 	groupNames := r.reVersion.SubexpNames()
 
 	mappedMatches := make(map[string]string)
@@ -239,7 +820,7 @@ func (r *renderer) parseVersionLine(line string) map[string]string {
 		mappedMatches[name] = value
 	}
 
-	return mappedMatches
+	return mappedMatches, true
 }
 
 // renderInline renders the node right away
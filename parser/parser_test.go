@@ -2,8 +2,11 @@ package parser_test
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/rcmachado/changelog/chg"
@@ -25,6 +28,7 @@ func TestParserParse(t *testing.T) {
 		input := readFile(t, "simple")
 
 		expected := &chg.Changelog{
+			Title:    "Changelog",
 			Preamble: "Simple paragraph.",
 			Versions: []*chg.Version{
 				{
@@ -34,13 +38,13 @@ func TestParserParse(t *testing.T) {
 						{
 							Type: chg.Added,
 							Items: []*chg.Item{
-								{"Awesome feature that people always asked for"},
+								{Description: "Awesome feature that people always asked for"},
 							},
 						},
 						{
 							Type: chg.Fixed,
 							Items: []*chg.Item{
-								{"That annoying bug"},
+								{Description: "That annoying bug"},
 							},
 						},
 					},
@@ -54,7 +58,7 @@ func TestParserParse(t *testing.T) {
 						{
 							Type: chg.Security,
 							Items: []*chg.Item{
-								{"Remote code execution using our eval endpoint"},
+								{Description: "Remote code execution using our eval endpoint"},
 							},
 						},
 					},
@@ -69,7 +73,8 @@ func TestParserParse(t *testing.T) {
 	t.Run("formatting", func(t *testing.T) {
 		input := readFile(t, "formatting")
 		expected := &chg.Changelog{
-			Preamble: `Nesciunt **voluptate** qui _consequatur_ eos_velit quia_aut. Qui
+			Title: "Changelog",
+			Preamble: `Nesciunt **voluptate** qui _consequatur_ eos\_velit quia_aut. Qui
 repellendus ~~et~~ impedit ` + "`minus`" + ` inventore. Dolorem numquam voluptate
 accusamus ut nihil. Aut quasi dolores quod accusamus provident facilis.
 Dolores et quidem consequatur qui sequi consequatur id. Magnam ea iure
@@ -124,6 +129,7 @@ and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0
 		input := readFile(t, "malformed")
 
 		expected := &chg.Changelog{
+			Title:    "Changelog",
 			Preamble: "Simple paragraph.",
 			Versions: []*chg.Version{
 				{
@@ -133,13 +139,13 @@ and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0
 						{
 							Type: chg.Added,
 							Items: []*chg.Item{
-								{"Awesome feature that people always asked for"},
+								{Description: "Awesome feature that people always asked for"},
 							},
 						},
 						{
 							Type: chg.Fixed,
 							Items: []*chg.Item{
-								{"That annoying bug"},
+								{Description: "That annoying bug"},
 							},
 						},
 					},
@@ -152,7 +158,7 @@ and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0
 						{
 							Type: chg.Security,
 							Items: []*chg.Item{
-								{"Remote code execution using our eval endpoint"},
+								{Description: "Remote code execution using our eval endpoint"},
 							},
 						},
 					},
@@ -168,6 +174,7 @@ and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0
 		input := readFile(t, "duplicated")
 
 		expected := &chg.Changelog{
+			Title:    "Changelog",
 			Preamble: "Simple paragraph.",
 			Versions: []*chg.Version{
 				{
@@ -177,8 +184,8 @@ and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0
 						{
 							Type: chg.Added,
 							Items: []*chg.Item{
-								{"Item 1"},
-								{"Item 2"},
+								{Description: "Item 1"},
+								{Description: "Item 2"},
 							},
 						},
 					},
@@ -190,3 +197,246 @@ and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0
 		assert.Equal(t, expected, result)
 	})
 }
+
+func TestParserParseCustomChangeTypes(t *testing.T) {
+	defer chg.SetChangeTypes(chg.DefaultChangeTypes)
+
+	err := chg.SetChangeTypes([]chg.ChangeTypeDef{
+		{Name: "Added", Heading: "Adicionado"},
+		{Name: "Fixed", Heading: "Corrigido", Aliases: []string{"Bugfixes"}},
+		{Name: "Performance"},
+	})
+	assert.NoError(t, err)
+
+	input := readFile(t, "custom-types")
+	result := parser.Parse(input)
+
+	v := result.Version("1.0.0")
+	assert.NotNil(t, v)
+	assert.Len(t, v.Changes, 3)
+
+	assert.Len(t, v.Change(chg.Added).Items, 1)
+	assert.Len(t, v.Change(chg.Fixed).Items, 2)
+	assert.Len(t, v.Change(chg.ChangeTypeFromString("Performance")).Items, 1)
+}
+
+func TestParserParseNestedItems(t *testing.T) {
+	input := readFile(t, "nested-items")
+	result := parser.Parse(input)
+
+	items := result.Version("Unreleased").Change(chg.Changed).Items
+	expected := []*chg.Item{
+		{
+			Description: "Configuration moved to `.changelog.yml`\nspanning two lines",
+			Body: []string{
+				"Rename the old file:",
+				"```bash\nmv .changelog .changelog.yml\n\n# Check it\nchangelog lint\n```",
+			},
+			Children: []*chg.Item{
+				{Description: "`types` is now `change_types`"},
+				{
+					Description: "`prefix` was split:",
+					Children: []*chg.Item{
+						{Description: "`tag_prefix`"},
+						{Description: "`version_prefix`"},
+					},
+				},
+			},
+		},
+		{Description: "Simple item"},
+	}
+	assert.Equal(t, expected, items)
+}
+
+func TestParserRenderNestedItems(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/nested-items.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	parser.Parse(bytes.NewReader(content)).Render(&buf)
+
+	assert.Equal(t, string(content), buf.String())
+}
+
+func TestParserRenderNestedItemsTwoSpaces(t *testing.T) {
+	input, err := ioutil.ReadFile("testdata/nested-items-2-spaces.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := ioutil.ReadFile("testdata/nested-items.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	parser.Parse(bytes.NewReader(input)).Render(&buf)
+
+	assert.Equal(t, string(expected), buf.String())
+}
+
+func TestParserRenderItemBlocksTwoSpaces(t *testing.T) {
+	input := "# Changelog\n\n## Unreleased\n### Added\n- Parent\n\n  > Quoted\n\n      indented code\n\n  1. First\n\n     Detail\n- Next\n"
+	expected := "# Changelog\n\n## Unreleased\n### Added\n- Parent\n\n    > Quoted\n\n    ```\n    indented code\n    ```\n\n    - First\n\n        Detail\n- Next\n"
+
+	var buf bytes.Buffer
+	parser.Parse(strings.NewReader(input)).Render(&buf)
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestParserRenderItemBlocks(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/item-blocks.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changelog := parser.Parse(bytes.NewReader(content))
+	item := changelog.Versions[0].Change(chg.Added).Items[0]
+	assert.Equal(t, "Feature C", item.Description)
+	assert.Equal(t, "> Quoted note\n> on two lines\n>\n> ```sh\n> make\n> ```", item.Body[0])
+	assert.Equal(t, "<details>\n<summary>Details</summary>\n</details>", item.Body[1])
+	assert.Equal(t, "Inline <kbd>Ctrl</kbd> key", item.Body[2])
+
+	var buf bytes.Buffer
+	changelog.Render(&buf)
+
+	assert.Equal(t, string(content), buf.String())
+}
+
+func TestParserHeadingEndsItem(t *testing.T) {
+	input := "# Changelog\n\n## Unreleased\n### Added\n- Feature B\n    - Detail\n### Fixed\n- Bug A\n- Bug B\n"
+	expected := "# Changelog\n\n## Unreleased\n### Added\n- Feature B\n    - Detail\n\n### Fixed\n- Bug A\n- Bug B\n"
+
+	changelog := parser.Parse(strings.NewReader(input))
+	v := changelog.Versions[0]
+	assert.Len(t, v.Change(chg.Added).Items, 1)
+	assert.Equal(t, []string(nil), v.Change(chg.Added).Items[0].Body)
+	assert.Len(t, v.Change(chg.Fixed).Items, 2)
+
+	var buf bytes.Buffer
+	changelog.Render(&buf)
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestParseItem(t *testing.T) {
+	text := "New API\nwith details\n\nSee the guide:\n\n```sh\nmake\n\nmake install\n```\n\n- First\n- Second"
+	expected := &chg.Item{
+		Description: "New API\nwith details",
+		Body:        []string{"See the guide:", "```sh\nmake\n\nmake install\n```"},
+		Children:    []*chg.Item{{Description: "First"}, {Description: "Second"}},
+	}
+
+	assert.Equal(t, expected, parser.ParseItem(text))
+	assert.Equal(t, &chg.Item{Description: "Fix crash"}, parser.ParseItem("Fix crash"))
+}
+
+func TestParserParseReferenceLinks(t *testing.T) {
+	input := readFile(t, "reference-links")
+	result := parser.Parse(input)
+
+	assert.Equal(t, "All notable changes are documented here, see the [guide][contributing].", result.Preamble)
+	assert.Equal(t, "https://example.com/compare/v1.0.0...HEAD", result.Version("Unreleased").Link)
+	assert.Equal(t, "https://example.com/releases/v1.0.0", result.Version("1.0.0").Link)
+
+	items := result.Version("Unreleased").Change(chg.Fixed).Items
+	assert.Equal(t, "Crash on empty files [#123]", items[0].Description)
+	assert.Equal(t, "Wrong date format ([report][#124], [docs](https://example.com/docs))", items[1].Description)
+
+	expected := []*chg.LinkDef{
+		{Label: "contributing", URL: "https://example.com/CONTRIBUTING.md", Title: "How to contribute"},
+		{Label: "#123", URL: "https://example.com/issues/123"},
+		{Label: "#124", URL: "https://example.com/issues/124"},
+	}
+	assert.Equal(t, expected, result.Links)
+}
+
+func TestParserRenderReferenceLinks(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/reference-links.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	parser.Parse(bytes.NewReader(content)).Render(&buf)
+
+	assert.Equal(t, string(content), buf.String())
+}
+
+func TestParserParsePreamble(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/preamble.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := parser.Parse(bytes.NewReader(content))
+
+	assert.Equal(t, "Release notes of [Project](https://example.com)", result.Title)
+	assert.Equal(t, []*chg.Item{{Description: "Item"}}, result.Version("Unreleased").Change(chg.Added).Items)
+
+	var buf bytes.Buffer
+	result.Render(&buf)
+	assert.Equal(t, string(content), buf.String())
+}
+
+func TestParserParseSetextTitle(t *testing.T) {
+	input := "Changes\n=======\n\nIntro\n\n## 1.0.0 - 2020-01-02\n"
+	result := parser.Parse(strings.NewReader(input))
+
+	assert.Equal(t, "Changes", result.Title)
+	assert.Equal(t, "Intro", result.Preamble)
+}
+
+func TestParserParseDates(t *testing.T) {
+	input := `# Changelog
+
+## 1.2.0 - January 31, 2024 [YANKED]
+## [1.1.0] - 31/01/2024
+## 1.0.0 - 2024.01.01
+`
+	result := parser.Parse(strings.NewReader(input))
+
+	assert.Equal(t, "January 31, 2024", result.Versions[0].Date)
+	assert.True(t, result.Versions[0].Yanked)
+	assert.Equal(t, "31/01/2024", result.Versions[1].Date)
+	assert.Equal(t, "2024.01.01", result.Versions[2].Date)
+
+	var buf bytes.Buffer
+	result.Render(&buf)
+	assert.Equal(t, "# Changelog\n\n## 1.2.0 - 2024-01-31 [YANKED]\n\n## 1.1.0 - 2024-01-31\n\n## 1.0.0 - 2024-01-01\n", buf.String())
+}
+
+func TestParserParseSuperseded(t *testing.T) {
+	input := `# Changelog
+
+## 2.0.0 - 2024-02-01
+
+## 2.0.0-rc.1 - 2024-01-15 [SUPERSEDED]
+`
+	result := parser.Parse(strings.NewReader(input))
+
+	assert.False(t, result.Versions[0].Superseded)
+	assert.True(t, result.Versions[1].Superseded)
+	assert.Equal(t, "2024-01-15", result.Versions[1].Date)
+
+	var buf bytes.Buffer
+	result.Render(&buf)
+	assert.Equal(t, input, buf.String())
+}
+
+func TestParserReadInvalidVersion(t *testing.T) {
+	_, err := parser.Read(strings.NewReader("# Changelog\n\n## [\n"))
+	assert.EqualError(t, err, "line 3: invalid version heading '['")
+
+	_, err = parser.Read(strings.NewReader("# Changelog\n\n## Release notes\n"))
+	assert.EqualError(t, err, "line 3: invalid version heading 'Release notes'")
+}
+
+func TestParserParseInlineVersionLink(t *testing.T) {
+	c := parser.Parse(strings.NewReader("# Changelog\n\n## [1.0.0](https://example.com/1.0.0) - 2020-01-01 [YANKED]\n"))
+
+	expected := &chg.Version{Name: "1.0.0", Date: "2020-01-01", Link: "https://example.com/1.0.0", Yanked: true}
+	assert.Equal(t, expected, c.Versions[0])
+}
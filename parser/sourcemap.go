@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+	blackfriday "github.com/russross/blackfriday/v2"
+)
+
+// Span is a range of lines of the input, starting at 0. End is inclusive.
+type Span struct {
+	Start int
+	End   int
+}
+
+// Warning is content found in the input that isn't part of the changelog
+type Warning struct {
+	Line    int // Starting at 0, like the spans
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("line %d: %s", w.Line+1, w.Message)
+}
+
+// SyntaxError is content of the input that can't be parsed, like a
+// version heading without a version
+type SyntaxError struct {
+	Line    int // Starting at 0, like the spans
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line+1, e.Message)
+}
+
+// SourceMap holds where the elements of a parsed changelog are in the input
+type SourceMap struct {
+	Lines    int                        // Number of lines of the input
+	Title    int                        // Line of the title, -1 if there is none
+	Versions map[*chg.Version]Span      // From the heading to the end of the version
+	Changes  map[*chg.ChangeList][]Span // One span per heading, as duplicated sections are merged
+	Items    map[*chg.Item]Span         // Lines of the list item
+	Headings map[*chg.ChangeList][]int  // Lines of the section headings
+	Links    map[string]int             // Lines of the link definitions, by lowercase label
+	Warnings []Warning
+	Errors   []*SyntaxError
+
+	// continued marks the lines without a marker that belong to the
+	// block in the previous line, like the closing line of a fence
+	continued []bool
+}
+
+func newSourceMap() *SourceMap {
+	return &SourceMap{
+		Title:    -1,
+		Versions: make(map[*chg.Version]Span),
+		Changes:  make(map[*chg.ChangeList][]Span),
+		Items:    make(map[*chg.Item]Span),
+		Headings: make(map[*chg.ChangeList][]int),
+		Links:    make(map[string]int),
+	}
+}
+
+// ParseWithSourceMap parses the input like Read and also returns where
+// each version, section and item is in the input. Syntax errors don't
+// stop the parsing: they are reported in the source map, with the
+// changelog parsed from the rest of the input.
+func ParseWithSourceMap(r io.Reader) (*chg.Changelog, *SourceMap, error) {
+	input, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changelog, m := parse(input)
+	return changelog, m, nil
+}
+
+// extend moves the end of the span over the lines continuing it
+func (m *SourceMap) extend(end int) int {
+	for end+1 < len(m.continued) && m.continued[end+1] {
+		end++
+	}
+	return end
+}
+
+var (
+	reLineMarker = regexp.MustCompile(" ?\x1e([0-9]+)\x1f")
+	reNoMarker   = regexp.MustCompile("^[-=*_#>+~` \t]*$|^[ \t]*[0-9]+[.)]$|>$")
+	reATXOpening = regexp.MustCompile(`^ {0,3}#+`)
+)
+
+// markLines appends to each line a marker with its number, which
+// blackfriday keeps in the text of the nodes. It's how the parser finds
+// where the nodes are, as blackfriday doesn't record their positions.
+//
+// Lines where the marker would change how the markdown is parsed, like
+// fences, setext underlines, rules and the end of HTML blocks, and the
+// link definitions aren't marked. The non-blank ones are continuations
+// of the previous lines.
+func markLines(input []byte, m *SourceMap) []byte {
+	var (
+		buf   bytes.Buffer
+		fence string
+	)
+
+	lines := strings.Split(strings.TrimSuffix(string(input), "\n"), "\n")
+	m.Lines = len(lines)
+	m.continued = make([]bool, len(lines))
+
+	for lineno, line := range strings.SplitAfter(string(input), "\n") {
+		content := strings.TrimRight(line, " \t\r\n")
+		matches := reFenceLine.FindStringSubmatch(content)
+		isFence := false
+		if matches != nil && fence == "" {
+			fence, isFence = matches[2], true
+		} else if matches != nil && matches[3] == "" && matches[2][0] == fence[0] && len(matches[2]) >= len(fence) {
+			fence, isFence = "", true
+		}
+
+		switch {
+		case content == "":
+		case fence == "" && reLinkDefLine.MatchString(content):
+		case isFence, reNoMarker.MatchString(content):
+			m.continued[lineno] = true
+		default:
+			marker := fmt.Sprintf(" \x1e%d\x1f", lineno)
+			if opening := reATXOpening.FindString(content); opening != "" && fence == "" {
+				// After the opening sequence, which keeps the marker
+				// before the optional closing one
+				line = opening + marker + line[len(opening):]
+			} else {
+				line = content + marker + line[len(content):]
+			}
+		}
+		buf.WriteString(line)
+	}
+
+	return buf.Bytes()
+}
+
+// removeMarkers removes the line markers from the text
+func removeMarkers(text string) string {
+	return reLineMarker.ReplaceAllString(text, "")
+}
+
+// readMarkers removes the line markers from the nodes and records the
+// lines of each node, including the ones of its children
+func readMarkers(ast *blackfriday.Node) map[*blackfriday.Node]Span {
+	spans := make(map[*blackfriday.Node]Span)
+
+	ast.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if !entering {
+			return blackfriday.GoToNext
+		}
+		if node.Type == blackfriday.CodeBlock {
+			// Fences not found by markLines, like the quoted ones, have
+			// the marker in the info string
+			node.Info = reLineMarker.ReplaceAll(node.Info, nil)
+		}
+		if len(node.Literal) == 0 {
+			return blackfriday.GoToNext
+		}
+
+		node.Literal = reLineMarker.ReplaceAllFunc(node.Literal, func(marker []byte) []byte {
+			line, _ := strconv.Atoi(string(reLineMarker.FindSubmatch(marker)[1]))
+			for n := node; n != nil; n = n.Parent {
+				span, ok := spans[n]
+				switch {
+				case !ok:
+					span = Span{Start: line, End: line}
+				case line < span.Start:
+					span.Start = line
+				case line > span.End:
+					span.End = line
+				}
+				spans[n] = span
+			}
+			return nil
+		})
+		return blackfriday.GoToNext
+	})
+
+	return spans
+}
@@ -0,0 +1,105 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/rcmachado/changelog/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWithSourceMap(t *testing.T) {
+	input := readFile(t, "sourcemap")
+
+	c, m, err := parser.ParseWithSourceMap(input)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 28, m.Lines)
+	assert.Equal(t, 0, m.Title)
+
+	unreleased := c.Version("Unreleased")
+	v100 := c.Version("1.0.0")
+	assert.Equal(t, parser.Span{Start: 4, End: 14}, m.Versions[unreleased])
+	assert.Equal(t, parser.Span{Start: 16, End: 24}, m.Versions[v100])
+
+	added := unreleased.Change(chg.Added)
+	assert.Equal(t, []parser.Span{{Start: 5, End: 8}, {Start: 13, End: 14}}, m.Changes[added])
+	assert.Equal(t, []int{5, 13}, m.Headings[added])
+
+	assert.Equal(t, parser.Span{Start: 6, End: 6}, m.Items[added.Items[0]])
+	assert.Equal(t, parser.Span{Start: 7, End: 8}, m.Items[added.Items[1]])
+	assert.Equal(t, parser.Span{Start: 14, End: 14}, m.Items[added.Items[2]])
+
+	fixed := v100.Change(chg.Fixed)
+	// blackfriday keeps the fenced code after the item in it
+	assert.Equal(t, parser.Span{Start: 20, End: 24}, m.Items[fixed.Items[0]])
+
+	assert.Equal(t, map[string]int{"unreleased": 26, "1.0.0": 27}, m.Links)
+
+	expected := []parser.Warning{
+		{Line: 10, Message: "unknown change type 'Performance', its items are ignored"},
+		{Line: 17, Message: "item outside of a change section is ignored"},
+	}
+	assert.Equal(t, expected, m.Warnings)
+}
+
+func TestParseWithSourceMapNestedItems(t *testing.T) {
+	input := readFile(t, "nested-items")
+
+	c, m, err := parser.ParseWithSourceMap(input)
+	assert.NoError(t, err)
+
+	changed := c.Version("Unreleased").Change(chg.Changed)
+	assert.Equal(t, parser.Span{Start: 4, End: 19}, m.Items[changed.Items[0]])
+	assert.Equal(t, parser.Span{Start: 20, End: 20}, m.Items[changed.Items[1]])
+	assert.Empty(t, m.Warnings)
+}
+
+func TestParseWithSourceMapCRLF(t *testing.T) {
+	input := "# Changelog\r\n\r\n## [Unreleased]\r\n### Added\r\n- Item 1\r\n- Item 2\r\n\r\n## 1.0.0 - 2020-01-01\r\n### Fixed\r\n- Item 3\r\n"
+
+	c, m, err := parser.ParseWithSourceMap(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, m.Title)
+	assert.Equal(t, parser.Span{Start: 2, End: 5}, m.Versions[c.Version("Unreleased")])
+	assert.Equal(t, parser.Span{Start: 7, End: 9}, m.Versions[c.Version("1.0.0")])
+	assert.Equal(t, []int{3}, m.Headings[c.Version("Unreleased").Change(chg.Added)])
+	assert.Equal(t, parser.Span{Start: 9, End: 9}, m.Items[c.Version("1.0.0").Change(chg.Fixed).Items[0]])
+}
+
+func TestParseWithSourceMapSetextHeadings(t *testing.T) {
+	input := `Changelog
+=========
+
+Unreleased
+----------
+### Added
+- Item 1
+
+1.0.0 - 2020-01-01
+------------------
+### Fixed
+- Item 2
+`
+
+	c, m, err := parser.ParseWithSourceMap(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, m.Title)
+	assert.Equal(t, parser.Span{Start: 3, End: 6}, m.Versions[c.Version("Unreleased")])
+	assert.Equal(t, parser.Span{Start: 8, End: 11}, m.Versions[c.Version("1.0.0")])
+	assert.Equal(t, parser.Span{Start: 11, End: 11}, m.Items[c.Version("1.0.0").Change(chg.Fixed).Items[0]])
+}
+
+func TestParseWithSourceMapInvalidVersion(t *testing.T) {
+	input := "# Changelog\n\n## [\n### Added\n- Item 1\n\n## 1.0.0\n### Fixed\n- Item 2\n"
+
+	c, m, err := parser.ParseWithSourceMap(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*parser.SyntaxError{{Line: 2, Message: "invalid version heading '['"}}, m.Errors)
+	assert.Len(t, c.Versions, 1)
+	assert.Equal(t, parser.Span{Start: 6, End: 8}, m.Versions[c.Version("1.0.0")])
+}
@@ -0,0 +1,154 @@
+package render
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// AsciiDoc renders the changelog as AsciiDoc, for Antora and other
+// docs-as-code tools. Versions have anchors like "version-1.0.0".
+type AsciiDoc struct{}
+
+var (
+	reAdocWord    = regexp.MustCompile(`\S+`)
+	reAdocSpecial = regexp.MustCompile("[*`#^~{\\[\\]]|<<|^[_+]|[_+]$")
+	reURLScheme   = regexp.MustCompile(`^[a-z][a-z0-9+.\-]*:`)
+)
+
+type adocSyntax struct{}
+
+// text protects the words with formatting characters in passthroughs
+func (adocSyntax) text(s string) string {
+	return reAdocWord.ReplaceAllStringFunc(s, func(word string) string {
+		if !reAdocSpecial.MatchString(word) || strings.Contains(word, "++") {
+			return word
+		}
+		return "++" + word + "++"
+	})
+}
+
+func (adocSyntax) code(s string) string {
+	return "`+" + s + "+`"
+}
+
+func (adocSyntax) emph(s string) string {
+	return "_" + s + "_"
+}
+
+func (adocSyntax) strong(s string) string {
+	return "*" + s + "*"
+}
+
+func (adocSyntax) link(text, url string) string {
+	if text == (adocSyntax{}).text(url) && reURLScheme.MatchString(url) {
+		return url
+	}
+	return "link:" + url + "[" + strings.Replace(text, "]", `\]`, -1) + "]"
+}
+
+// Changelog writes the whole changelog
+func (a AsciiDoc) Changelog(w io.Writer, c *chg.Changelog) error {
+	io.WriteString(w, "= "+adocSyntax{}.text(changelogTitle(c))+"\n")
+	if c.Preamble != "" {
+		io.WriteString(w, "\n")
+		writeAdocBlocks(w, convertMarkdown(c.Preamble, c, adocSyntax{}))
+	}
+	if len(c.Versions) > 0 {
+		io.WriteString(w, "\n")
+	}
+	return a.Versions(w, c, c.Versions)
+}
+
+// Version writes the version
+func (a AsciiDoc) Version(w io.Writer, c *chg.Changelog, v *chg.Version) error {
+	return a.Versions(w, c, []*chg.Version{v})
+}
+
+// Versions writes the versions as sections with their changes
+func (AsciiDoc) Versions(w io.Writer, c *chg.Changelog, versions []*chg.Version) error {
+	syntax := adocSyntax{}
+	for idx, v := range versions {
+		if idx > 0 {
+			io.WriteString(w, "\n")
+		}
+		v.SortChanges()
+
+		io.WriteString(w, "[#"+versionAnchor(v)+"]\n")
+		io.WriteString(w, "== "+versionTitle(v, syntax)+"\n")
+
+		for _, change := range v.Changes {
+			io.WriteString(w, "\n=== "+syntax.text(change.Type.String())+"\n\n")
+
+			var items [][]*block
+			for _, item := range change.Items {
+				items = append(items, convertItem(item, c, syntax))
+			}
+			writeAdocList(w, items, 1)
+		}
+	}
+	return nil
+}
+
+// writeAdocBlocks writes the blocks separated by blank lines
+func writeAdocBlocks(w io.Writer, blocks []*block) {
+	for idx, b := range blocks {
+		if idx > 0 {
+			io.WriteString(w, "\n")
+		}
+		if b.kind == listBlock {
+			writeAdocList(w, b.items, 1)
+		} else {
+			writeAdocBlock(w, b)
+		}
+	}
+}
+
+func writeAdocBlock(w io.Writer, b *block) {
+	switch b.kind {
+	case paragraphBlock:
+		io.WriteString(w, b.text+"\n")
+	case headingBlock:
+		// The document title is the only level 0 section
+		level := b.level
+		if level < 2 {
+			level = 2
+		}
+		io.WriteString(w, strings.Repeat("=", level)+" "+b.text+"\n")
+	case codeBlock:
+		if b.info != "" {
+			io.WriteString(w, "[source,"+strings.Fields(b.info)[0]+"]\n")
+		}
+		io.WriteString(w, "----\n"+b.text+"\n----\n")
+	case quoteBlock:
+		io.WriteString(w, "____\n")
+		writeAdocBlocks(w, b.children)
+		io.WriteString(w, "____\n")
+	}
+}
+
+// writeAdocList writes the items with markers of the depth, eg. "**".
+// The blocks after the first paragraph are attached to the item with
+// list continuations.
+func writeAdocList(w io.Writer, items [][]*block, depth int) {
+	marker := strings.Repeat("*", depth)
+	for _, blocks := range items {
+		if len(blocks) > 0 && blocks[0].kind == paragraphBlock {
+			io.WriteString(w, marker+" "+blocks[0].text+"\n")
+			blocks = blocks[1:]
+		} else {
+			io.WriteString(w, marker+" {empty}\n")
+		}
+
+		for _, b := range blocks {
+			if b.kind == listBlock {
+				writeAdocList(w, b.items, depth+1)
+				continue
+			}
+			io.WriteString(w, "+\n")
+			writeAdocBlock(w, b)
+		}
+	}
+}
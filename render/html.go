@@ -0,0 +1,49 @@
+package render
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/rcmachado/changelog/chg"
+	blackfriday "github.com/russross/blackfriday/v2"
+)
+
+// HTML renders the markdown output as an HTML fragment
+type HTML struct {
+	Style *chg.Style // Normalized style of the markdown, see Markdown
+}
+
+// Changelog writes the whole changelog
+func (h HTML) Changelog(w io.Writer, c *chg.Changelog) error {
+	var buf bytes.Buffer
+	if err := (Markdown{Style: h.Style}).Changelog(&buf, c); err != nil {
+		return err
+	}
+	return writeHTML(w, buf.Bytes())
+}
+
+// Version writes the version
+func (h HTML) Version(w io.Writer, c *chg.Changelog, v *chg.Version) error {
+	return h.Versions(w, c, []*chg.Version{v})
+}
+
+// Versions writes the versions
+func (h HTML) Versions(w io.Writer, c *chg.Changelog, versions []*chg.Version) error {
+	var buf bytes.Buffer
+	if err := (Markdown{Style: h.Style}).Versions(&buf, c, versions); err != nil {
+		return err
+	}
+	return writeHTML(w, buf.Bytes())
+}
+
+// writeHTML converts the markdown to HTML. Raw HTML in the changelog
+// is dropped and only safe links are kept, as the output is served and
+// published as is.
+func writeHTML(w io.Writer, markdown []byte) error {
+	renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.SkipHTML | blackfriday.Safelink,
+	})
+	html := blackfriday.Run(markdown, blackfriday.WithExtensions(blackfriday.CommonExtensions), blackfriday.WithRenderer(renderer))
+	_, err := w.Write(html)
+	return err
+}
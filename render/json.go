@@ -0,0 +1,95 @@
+package render
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// JSON renders the changelog as JSON documents
+type JSON struct{}
+
+type jsonChangelog struct {
+	Title    string         `json:"title,omitempty"`
+	Preamble string         `json:"preamble"`
+	Versions []*jsonVersion `json:"versions"`
+	Links    []*jsonLink    `json:"links,omitempty"`
+}
+
+type jsonLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+	Title string `json:"title,omitempty"`
+}
+
+type jsonVersion struct {
+	Name       string        `json:"name"`
+	Date       string        `json:"date,omitempty"`
+	Link       string        `json:"link,omitempty"`
+	Yanked     bool          `json:"yanked"`
+	Superseded bool          `json:"superseded,omitempty"`
+	Changes    []*jsonChange `json:"changes"`
+}
+
+type jsonChange struct {
+	Type  string   `json:"type"`
+	Items []string `json:"items"`
+}
+
+// Changelog writes the changelog as an object with its title, preamble,
+// versions and link definitions
+func (JSON) Changelog(w io.Writer, c *chg.Changelog) error {
+	var links []*jsonLink
+	for _, l := range c.Links {
+		links = append(links, &jsonLink{Label: l.Label, URL: l.URL, Title: l.Title})
+	}
+
+	return encodeJSON(w, jsonChangelog{
+		Title:    c.Title,
+		Preamble: c.Preamble,
+		Versions: newJSONVersions(c.Versions),
+		Links:    links,
+	})
+}
+
+// Version writes the version as an object
+func (JSON) Version(w io.Writer, c *chg.Changelog, v *chg.Version) error {
+	return encodeJSON(w, newJSONVersions([]*chg.Version{v})[0])
+}
+
+// Versions writes an array of versions
+func (JSON) Versions(w io.Writer, c *chg.Changelog, versions []*chg.Version) error {
+	return encodeJSON(w, newJSONVersions(versions))
+}
+
+func newJSONVersions(versions []*chg.Version) []*jsonVersion {
+	result := make([]*jsonVersion, 0, len(versions))
+	for _, v := range versions {
+		v.SortChanges()
+
+		jv := &jsonVersion{
+			Name:       v.Name,
+			Date:       chg.NormalizeDate(v.Date),
+			Link:       v.Link,
+			Yanked:     v.Yanked,
+			Superseded: v.Superseded,
+			Changes:    make([]*jsonChange, 0, len(v.Changes)),
+		}
+		for _, c := range v.Changes {
+			jc := &jsonChange{Type: c.Type.String(), Items: make([]string, 0, len(c.Items))}
+			for _, item := range c.Items {
+				jc.Items = append(jc.Items, item.Text())
+			}
+			jv.Changes = append(jv.Changes, jc)
+		}
+		result = append(result, jv)
+	}
+	return result
+}
+
+func encodeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
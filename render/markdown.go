@@ -0,0 +1,65 @@
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// Markdown renders the keepachangelog.com format, the same used by the
+// changelog file
+type Markdown struct {
+	Style *chg.Style // Normalized style to use instead of the configured one
+}
+
+// Changelog writes the whole changelog
+func (m Markdown) Changelog(w io.Writer, c *chg.Changelog) error {
+	c.RenderStyle(w, m.style())
+	return nil
+}
+
+// Version writes the version followed by its link definitions
+func (m Markdown) Version(w io.Writer, c *chg.Changelog, v *chg.Version) error {
+	return m.Versions(w, c, []*chg.Version{v})
+}
+
+// Versions writes the versions followed by their link definitions and
+// the definitions referenced by their items
+func (m Markdown) Versions(w io.Writer, c *chg.Changelog, versions []*chg.Version) error {
+	for idx, v := range versions {
+		if idx > 0 {
+			io.WriteString(w, "\n")
+		}
+		v.SortChanges()
+		v.RenderStyle(w, m.style())
+	}
+
+	links := false
+	for _, v := range versions {
+		if v.Link == "" {
+			continue
+		}
+		if !links {
+			io.WriteString(w, "\n")
+			links = true
+		}
+		fmt.Fprintf(w, "[%s]: %s\n", v.Name, v.Link)
+	}
+	for _, l := range c.UsedLinks(versions) {
+		if !links {
+			io.WriteString(w, "\n")
+			links = true
+		}
+		l.Render(w)
+	}
+	return nil
+}
+
+// style returns the style of the renderer, or the configured one
+func (m Markdown) style() chg.Style {
+	if m.Style != nil {
+		return *m.Style
+	}
+	return chg.CurrentStyle()
+}
@@ -0,0 +1,193 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rcmachado/changelog/chg"
+	blackfriday "github.com/russross/blackfriday/v2"
+)
+
+// inlineSyntax converts the inline markdown elements to the syntax of a
+// markup language
+type inlineSyntax interface {
+	text(s string) string
+	code(s string) string
+	emph(s string) string
+	strong(s string) string
+	link(text, url string) string
+}
+
+type blockKind int
+
+const (
+	paragraphBlock blockKind = iota
+	headingBlock
+	codeBlock
+	listBlock
+	quoteBlock
+)
+
+// block is a markdown block with its inline content already converted
+type block struct {
+	kind     blockKind
+	text     string     // Converted text, or the code of code blocks
+	info     string     // Language of code blocks
+	level    int        // Level of headings
+	items    [][]*block // Items of lists
+	children []*block   // Content of quotes
+}
+
+// convertMarkdown parses the markdown text into blocks. The link
+// definitions of the changelog are used to resolve reference-style
+// links.
+func convertMarkdown(text string, c *chg.Changelog, syntax inlineSyntax) []*block {
+	var buf bytes.Buffer
+	buf.WriteString(text)
+	buf.WriteString("\n\n")
+	for _, v := range c.Versions {
+		if v.Link != "" {
+			fmt.Fprintf(&buf, "[%s]: %s\n", v.Name, v.Link)
+		}
+	}
+	for _, l := range c.Links {
+		l.Render(&buf)
+	}
+
+	md := blackfriday.New(blackfriday.WithExtensions(blackfriday.CommonExtensions))
+	return convertBlocks(md.Parse(buf.Bytes()), syntax)
+}
+
+// convertItem returns the blocks of the item: its description, body
+// and a list with the children
+func convertItem(item *chg.Item, c *chg.Changelog, syntax inlineSyntax) []*block {
+	text := item.Description
+	for _, b := range item.Body {
+		text += "\n\n" + b
+	}
+	blocks := convertMarkdown(text, c, syntax)
+
+	if len(item.Children) > 0 {
+		list := &block{kind: listBlock}
+		for _, child := range item.Children {
+			list.items = append(list.items, convertItem(child, c, syntax))
+		}
+		blocks = append(blocks, list)
+	}
+	return blocks
+}
+
+func convertBlocks(parent *blackfriday.Node, syntax inlineSyntax) []*block {
+	var blocks []*block
+	for node := parent.FirstChild; node != nil; node = node.Next {
+		switch node.Type {
+		case blackfriday.Paragraph:
+			blocks = append(blocks, &block{kind: paragraphBlock, text: convertInline(node, syntax)})
+		case blackfriday.Heading:
+			blocks = append(blocks, &block{kind: headingBlock, text: convertInline(node, syntax), level: node.Level})
+		case blackfriday.CodeBlock:
+			blocks = append(blocks, &block{
+				kind: codeBlock,
+				text: strings.TrimRight(string(node.Literal), "\n"),
+				info: strings.TrimSpace(string(node.Info)),
+			})
+		case blackfriday.List:
+			list := &block{kind: listBlock}
+			for item := node.FirstChild; item != nil; item = item.Next {
+				list.items = append(list.items, convertBlocks(item, syntax))
+			}
+			blocks = append(blocks, list)
+		case blackfriday.BlockQuote:
+			blocks = append(blocks, &block{kind: quoteBlock, children: convertBlocks(node, syntax)})
+		case blackfriday.HTMLBlock:
+			blocks = append(blocks, &block{kind: codeBlock, text: strings.TrimRight(string(node.Literal), "\n"), info: "html"})
+		case blackfriday.HorizontalRule:
+			// No equivalent inside sections
+		default:
+			blocks = append(blocks, convertBlocks(node, syntax)...)
+		}
+	}
+	return blocks
+}
+
+func convertInline(parent *blackfriday.Node, syntax inlineSyntax) string {
+	var buf strings.Builder
+	for node := parent.FirstChild; node != nil; node = node.Next {
+		switch node.Type {
+		case blackfriday.Text, blackfriday.HTMLSpan:
+			buf.WriteString(syntax.text(string(node.Literal)))
+		case blackfriday.Code:
+			buf.WriteString(syntax.code(string(node.Literal)))
+		case blackfriday.Emph:
+			buf.WriteString(syntax.emph(convertInline(node, syntax)))
+		case blackfriday.Strong:
+			buf.WriteString(syntax.strong(convertInline(node, syntax)))
+		case blackfriday.Link, blackfriday.Image:
+			buf.WriteString(syntax.link(convertInline(node, syntax), string(node.LinkData.Destination)))
+		case blackfriday.Softbreak, blackfriday.Hardbreak:
+			buf.WriteString(" ")
+		default:
+			buf.WriteString(convertInline(node, syntax))
+		}
+	}
+	return buf.String()
+}
+
+// plainText returns the text without the inline markup
+func plainText(markdown string) string {
+	return convertInline(firstParagraph(markdown), plainSyntax{})
+}
+
+func firstParagraph(markdown string) *blackfriday.Node {
+	doc := blackfriday.New(blackfriday.WithExtensions(blackfriday.CommonExtensions)).Parse([]byte(markdown))
+	if doc.FirstChild != nil {
+		return doc.FirstChild
+	}
+	return doc
+}
+
+// plainSyntax drops the inline markup
+type plainSyntax struct{}
+
+func (plainSyntax) text(s string) string         { return s }
+func (plainSyntax) code(s string) string         { return s }
+func (plainSyntax) emph(s string) string         { return s }
+func (plainSyntax) strong(s string) string       { return s }
+func (plainSyntax) link(text, url string) string { return text }
+
+var reAnchor = regexp.MustCompile(`[^a-z0-9.\-]+`)
+
+// versionAnchor returns the id used to link to the version, eg.
+// "version-1.0.0"
+func versionAnchor(v *chg.Version) string {
+	return "version-" + strings.Trim(reAnchor.ReplaceAllString(strings.ToLower(v.Name), "-"), "-")
+}
+
+// versionTitle returns the name of the version, linked when it has a
+// link, with its date and yanked tag
+func versionTitle(v *chg.Version, syntax inlineSyntax) string {
+	title := syntax.text(v.Name)
+	if v.Link != "" {
+		title = syntax.link(title, v.Link)
+	}
+	if v.Date != "" {
+		title += syntax.text(" - " + chg.NormalizeDate(v.Date))
+	}
+	if v.Yanked {
+		title += syntax.text(" [YANKED]")
+	}
+	if v.Superseded {
+		title += syntax.text(" [SUPERSEDED]")
+	}
+	return title
+}
+
+// changelogTitle returns the title of the document
+func changelogTitle(c *chg.Changelog) string {
+	if c.Title == "" {
+		return "Changelog"
+	}
+	return plainText(c.Title)
+}
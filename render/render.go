@@ -0,0 +1,49 @@
+// Package render outputs changelogs in formats other than the
+// keepachangelog.com markdown handled by chg
+package render
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// Renderer writes a changelog, or some of its versions, in a format
+type Renderer interface {
+	Changelog(w io.Writer, c *chg.Changelog) error
+	// Version and Versions receive the changelog of the versions, which
+	// holds the link definitions used by their items
+	Version(w io.Writer, c *chg.Changelog, v *chg.Version) error
+	Versions(w io.Writer, c *chg.Changelog, versions []*chg.Version) error
+}
+
+var renderers = map[string]Renderer{
+	"markdown": Markdown{},
+	"json":     JSON{},
+	"html":     HTML{},
+	"rst":      RST{},
+	"asciidoc": AsciiDoc{},
+	"text":     Text{},
+	"ansi":     Text{Color: true},
+}
+
+// New returns the renderer for the format
+func New(format string) (Renderer, error) {
+	r, ok := renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("Unknown format: '%s'", format)
+	}
+	return r, nil
+}
+
+// Formats lists the available formats
+func Formats() []string {
+	formats := make([]string, 0, len(renderers))
+	for name := range renderers {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats)
+	return formats
+}
@@ -0,0 +1,397 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rcmachado/changelog/chg"
+	"github.com/stretchr/testify/assert"
+)
+
+func testChangelog() *chg.Changelog {
+	return &chg.Changelog{
+		Preamble: "Notable changes.",
+		Versions: []*chg.Version{
+			{
+				Name: "Unreleased",
+				Link: "https://example.com/compare/1.0.0...HEAD",
+				Changes: []*chg.ChangeList{
+					{Type: chg.Removed, Items: []*chg.Item{{Description: "Item 3"}}},
+				},
+			},
+			{
+				Name: "1.0.0",
+				Date: "2020-01-08",
+				Changes: []*chg.ChangeList{
+					{Type: chg.Fixed, Items: []*chg.Item{{Description: "Item 2"}}},
+					{Type: chg.Added, Items: []*chg.Item{{Description: "Item 1"}}},
+				},
+			},
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, format := range Formats() {
+		r, err := New(format)
+		assert.Nil(t, err)
+		assert.NotNil(t, r)
+	}
+
+	_, err := New("pdf")
+	assert.EqualError(t, err, "Unknown format: 'pdf'")
+}
+
+func TestFormats(t *testing.T) {
+	assert.Equal(t, []string{"ansi", "asciidoc", "html", "json", "markdown", "rst", "text"}, Formats())
+}
+
+func TestMarkdownVersions(t *testing.T) {
+	var buf bytes.Buffer
+	err := Markdown{}.Versions(&buf, testChangelog(), testChangelog().Versions)
+
+	expected := `## [Unreleased]
+### Removed
+- Item 3
+
+## 1.0.0 - 2020-01-08
+### Added
+- Item 1
+
+### Fixed
+- Item 2
+
+[Unreleased]: https://example.com/compare/1.0.0...HEAD
+`
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestJSONChangelog(t *testing.T) {
+	var buf bytes.Buffer
+	err := JSON{}.Changelog(&buf, testChangelog())
+
+	expected := `{
+  "preamble": "Notable changes.",
+  "versions": [
+    {
+      "name": "Unreleased",
+      "link": "https://example.com/compare/1.0.0...HEAD",
+      "yanked": false,
+      "changes": [
+        {
+          "type": "Removed",
+          "items": [
+            "Item 3"
+          ]
+        }
+      ]
+    },
+    {
+      "name": "1.0.0",
+      "date": "2020-01-08",
+      "yanked": false,
+      "changes": [
+        {
+          "type": "Added",
+          "items": [
+            "Item 1"
+          ]
+        },
+        {
+          "type": "Fixed",
+          "items": [
+            "Item 2"
+          ]
+        }
+      ]
+    }
+  ]
+}
+`
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestJSONVersion(t *testing.T) {
+	var buf bytes.Buffer
+	err := JSON{}.Version(&buf, testChangelog(), &chg.Version{Name: "1.0.0", Yanked: true})
+
+	expected := `{
+  "name": "1.0.0",
+  "yanked": true,
+  "changes": []
+}
+`
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestJSONVersionsEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	err := JSON{}.Versions(&buf, testChangelog(), nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestHTMLVersions(t *testing.T) {
+	var buf bytes.Buffer
+	err := HTML{}.Versions(&buf, testChangelog(), testChangelog().Versions[:1])
+
+	expected := `<h2><a href="https://example.com/compare/1.0.0...HEAD">Unreleased</a></h2>
+
+<h3>Removed</h3>
+
+<ul>
+<li>Item 3</li>
+</ul>
+`
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestHTMLVersionsReferenceLinks(t *testing.T) {
+	c := &chg.Changelog{
+		Versions: []*chg.Version{
+			{
+				Name: "1.0.0",
+				Changes: []*chg.ChangeList{
+					{Type: chg.Fixed, Items: []*chg.Item{{Description: "Crash [#12]"}}},
+				},
+			},
+		},
+		Links: []*chg.LinkDef{
+			{Label: "#12", URL: "https://example.com/issues/12"},
+			{Label: "#13", URL: "https://example.com/issues/13"},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := HTML{}.Versions(&buf, c, c.Versions)
+
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), `<li>Crash <a href="https://example.com/issues/12">#12</a></li>`)
+	assert.NotContains(t, buf.String(), "issues/13")
+}
+
+func TestHTMLVersionsUnsafeContent(t *testing.T) {
+	c := &chg.Changelog{
+		Versions: []*chg.Version{
+			{
+				Name: "1.0.0",
+				Changes: []*chg.ChangeList{
+					{Type: chg.Fixed, Items: []*chg.Item{
+						{Description: "Crash <script>alert(1)</script>"},
+						{Description: "Link [here](javascript:alert(1))"},
+						{Description: "Tags", Body: []string{"<div onclick=\"alert(1)\">x</div>"}},
+					}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := HTML{}.Versions(&buf, c, c.Versions)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, buf.String(), "<script")
+	assert.NotContains(t, buf.String(), "javascript:")
+	assert.NotContains(t, buf.String(), "onclick")
+	assert.Contains(t, buf.String(), "Crash alert(1)")
+}
+
+func markupChangelog() *chg.Changelog {
+	return &chg.Changelog{
+		Preamble: "Notable *changes* of `tool`.",
+		Versions: []*chg.Version{
+			{
+				Name: "1.0.0",
+				Date: "2020-01-08",
+				Link: "https://example.com/v1.0.0",
+				Changes: []*chg.ChangeList{
+					{Type: chg.Added, Items: []*chg.Item{
+						{Description: "Support `--format` with **strong** text [#12]"},
+						{
+							Description: "Nested item",
+							Body:        []string{"See the [docs](https://example.com/docs).", "```go\nfmt.Println(\"x\")\n```"},
+							Children:    []*chg.Item{{Description: "Child a*b"}},
+						},
+					}},
+				},
+			},
+		},
+		Links: []*chg.LinkDef{{Label: "#12", URL: "https://example.com/issues/12"}},
+	}
+}
+
+func TestRSTChangelog(t *testing.T) {
+	var buf bytes.Buffer
+	err := RST{}.Changelog(&buf, markupChangelog())
+
+	expected := "Changelog\n" +
+		"=========\n" +
+		"\n" +
+		"Notable *changes* of ``tool``.\n" +
+		"\n" +
+		".. _version-1.0.0:\n" +
+		"\n" +
+		"`1.0.0 <https://example.com/v1.0.0>`__ - 2020-01-08\n" +
+		"---------------------------------------------------\n" +
+		"\n" +
+		"Added\n" +
+		"~~~~~\n" +
+		"\n" +
+		"- Support ``--format`` with **strong** text `#12 <https://example.com/issues/12>`__\n" +
+		"\n" +
+		"- Nested item\n" +
+		"\n" +
+		"  See the `docs <https://example.com/docs>`__.\n" +
+		"\n" +
+		"  .. code-block:: go\n" +
+		"\n" +
+		"     fmt.Println(\"x\")\n" +
+		"\n" +
+		"  - Child a\\*b\n"
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRSTVersionsMultilineParagraphs(t *testing.T) {
+	var buf bytes.Buffer
+	versions := []*chg.Version{{
+		Name: "1.0.0",
+		Changes: []*chg.ChangeList{
+			{Type: chg.Fixed, Items: []*chg.Item{
+				{
+					Description: "foo\ncontinuation line",
+					Body:        []string{"Details\nspanning lines"},
+					Children:    []*chg.Item{{Description: "child\ncontinued"}},
+				},
+			}},
+		},
+	}}
+	err := RST{}.Versions(&buf, testChangelog(), versions)
+
+	expected := ".. _version-1.0.0:\n" +
+		"\n" +
+		"1.0.0\n" +
+		"-----\n" +
+		"\n" +
+		"Fixed\n" +
+		"~~~~~\n" +
+		"\n" +
+		"- foo\n" +
+		"  continuation line\n" +
+		"\n" +
+		"  Details\n" +
+		"  spanning lines\n" +
+		"\n" +
+		"  - child\n" +
+		"    continued\n"
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRSTVersionsYanked(t *testing.T) {
+	var buf bytes.Buffer
+	err := RST{}.Versions(&buf, testChangelog(), []*chg.Version{{Name: "0.1.0_", Yanked: true}})
+
+	expected := `.. _version-0.1.0:
+
+0.1.0\_ [YANKED]
+----------------
+`
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestAsciiDocChangelog(t *testing.T) {
+	var buf bytes.Buffer
+	err := AsciiDoc{}.Changelog(&buf, markupChangelog())
+
+	expected := "= Changelog\n" +
+		"\n" +
+		"Notable _changes_ of `+tool+`.\n" +
+		"\n" +
+		"[#version-1.0.0]\n" +
+		"== link:https://example.com/v1.0.0[1.0.0] - 2020-01-08\n" +
+		"\n" +
+		"=== Added\n" +
+		"\n" +
+		"* Support `+--format+` with *strong* text link:https://example.com/issues/12[++#12++]\n" +
+		"* Nested item\n" +
+		"+\n" +
+		"See the link:https://example.com/docs[docs].\n" +
+		"+\n" +
+		"[source,go]\n" +
+		"----\n" +
+		"fmt.Println(\"x\")\n" +
+		"----\n" +
+		"** Child ++a*b++\n"
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestTextChangelog(t *testing.T) {
+	var buf bytes.Buffer
+	err := Text{Width: 30}.Changelog(&buf, markupChangelog())
+
+	expected := `Changelog
+=========
+
+Notable changes of tool.
+
+1.0.0 - 2020-01-08
+------------------
+
+Added:
+  - Support --format with
+    strong text #12
+    (https://example.com/issues/12)
+  - Nested item
+
+    See the docs
+    (https://example.com/docs).
+
+        fmt.Println("x")
+
+    - Child a*b
+`
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestTextVersionsColor(t *testing.T) {
+	c := &chg.Changelog{
+		Versions: []*chg.Version{
+			{
+				Name:   "1.0.0",
+				Date:   "2020-01-08",
+				Yanked: true,
+				Changes: []*chg.ChangeList{
+					{Type: chg.Fixed, Items: []*chg.Item{{Description: "Crash in `parse`"}}},
+					{Type: chg.Added, Items: []*chg.Item{{Description: "Item"}}},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := Text{Color: true}.Versions(&buf, c, c.Versions)
+
+	expected := "\x1b[1m1.0.0 - 2020-01-08\x1b[0m \x1b[31m\x1b[1m[YANKED]\x1b[0m\n" +
+		"\n" +
+		"\x1b[32mAdded:\x1b[0m\n" +
+		"  - Item\n" +
+		"\n" +
+		"\x1b[34mFixed:\x1b[0m\n" +
+		"  - Crash in \x1b[36mparse\x1b[0m\n"
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, "one two\nthree\nextraordinary", wrapText("one two three extraordinary", 8))
+	assert.Equal(t, "\x1b[1mone\x1b[0m two\nthree", wrapText("\x1b[1mone\x1b[0m two three", 8))
+}
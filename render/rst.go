@@ -0,0 +1,180 @@
+package render
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// RST renders the changelog as reStructuredText, for Sphinx
+// documentation. Versions have anchors like "version-1.0.0".
+type RST struct{}
+
+// rstUnderlines are the underline characters of the title, versions,
+// change types and deeper headings
+var rstUnderlines = []string{"=", "-", "~", "^", "\""}
+
+var (
+	reRSTSpecial    = regexp.MustCompile("([\\\\*`|])")
+	reRSTUnderscore = regexp.MustCompile(`_(\W|$)`)
+)
+
+type rstSyntax struct{}
+
+func (rstSyntax) text(s string) string {
+	s = reRSTSpecial.ReplaceAllString(s, `\$1`)
+	return reRSTUnderscore.ReplaceAllString(s, `\_$1`)
+}
+
+func (rstSyntax) code(s string) string {
+	return "``" + s + "``"
+}
+
+func (rstSyntax) emph(s string) string {
+	return "*" + s + "*"
+}
+
+func (rstSyntax) strong(s string) string {
+	return "**" + s + "**"
+}
+
+func (rstSyntax) link(text, url string) string {
+	if text == (rstSyntax{}).text(url) {
+		return url
+	}
+	return "`" + text + " <" + url + ">`__"
+}
+
+// Changelog writes the whole changelog
+func (r RST) Changelog(w io.Writer, c *chg.Changelog) error {
+	writeRSTHeading(w, rstSyntax{}.text(changelogTitle(c)), 1)
+	if c.Preamble != "" {
+		io.WriteString(w, "\n")
+		writeRSTBlocks(w, convertMarkdown(c.Preamble, c, rstSyntax{}), "")
+	}
+	if len(c.Versions) > 0 {
+		io.WriteString(w, "\n")
+	}
+	return r.Versions(w, c, c.Versions)
+}
+
+// Version writes the version
+func (r RST) Version(w io.Writer, c *chg.Changelog, v *chg.Version) error {
+	return r.Versions(w, c, []*chg.Version{v})
+}
+
+// Versions writes the versions as sections with their changes
+func (RST) Versions(w io.Writer, c *chg.Changelog, versions []*chg.Version) error {
+	syntax := rstSyntax{}
+	for idx, v := range versions {
+		if idx > 0 {
+			io.WriteString(w, "\n")
+		}
+		v.SortChanges()
+
+		io.WriteString(w, ".. _"+versionAnchor(v)+":\n\n")
+		writeRSTHeading(w, versionTitle(v, syntax), 2)
+
+		for _, change := range v.Changes {
+			io.WriteString(w, "\n")
+			writeRSTHeading(w, syntax.text(change.Type.String()), 3)
+			io.WriteString(w, "\n")
+
+			var items [][]*block
+			for _, item := range change.Items {
+				items = append(items, convertItem(item, c, syntax))
+			}
+			writeRSTList(w, items, "")
+		}
+	}
+	return nil
+}
+
+func writeRSTHeading(w io.Writer, text string, level int) {
+	if level > len(rstUnderlines) {
+		level = len(rstUnderlines)
+	}
+	io.WriteString(w, text+"\n")
+	io.WriteString(w, strings.Repeat(rstUnderlines[level-1], utf8.RuneCountInString(text))+"\n")
+}
+
+// writeRSTBlocks writes the blocks separated by blank lines
+func writeRSTBlocks(w io.Writer, blocks []*block, indent string) {
+	for idx, b := range blocks {
+		if idx > 0 {
+			io.WriteString(w, "\n")
+		}
+
+		switch b.kind {
+		case paragraphBlock:
+			writeIndented(w, b.text, indent)
+		case headingBlock:
+			io.WriteString(w, indent)
+			writeRSTHeading(w, b.text, b.level)
+		case codeBlock:
+			if b.info != "" {
+				io.WriteString(w, indent+".. code-block:: "+strings.Fields(b.info)[0]+"\n\n")
+			} else {
+				io.WriteString(w, indent+"::\n\n")
+			}
+			writeIndented(w, b.text, indent+"   ")
+		case quoteBlock:
+			writeRSTBlocks(w, b.children, indent+"   ")
+		case listBlock:
+			writeRSTList(w, b.items, indent)
+		}
+	}
+}
+
+// writeRSTList writes the items, separated by blank lines when some of
+// them have nested content
+func writeRSTList(w io.Writer, items [][]*block, indent string) {
+	loose := false
+	for _, item := range items {
+		loose = loose || len(item) > 1
+	}
+
+	for idx, item := range items {
+		if idx > 0 && loose {
+			io.WriteString(w, "\n")
+		}
+		writeRSTItem(w, item, indent)
+	}
+}
+
+// writeRSTItem writes the list item, with its content aligned to the
+// text of the first paragraph
+func writeRSTItem(w io.Writer, blocks []*block, indent string) {
+	if len(blocks) == 0 || blocks[0].kind != paragraphBlock {
+		io.WriteString(w, indent+"-\n")
+	} else {
+		first, rest := blocks[0].text, ""
+		if idx := strings.Index(first, "\n"); idx >= 0 {
+			first, rest = first[:idx], first[idx+1:]
+		}
+		io.WriteString(w, indent+"- "+first+"\n")
+		if rest != "" {
+			writeIndented(w, rest, indent+"  ")
+		}
+		blocks = blocks[1:]
+	}
+	if len(blocks) > 0 {
+		io.WriteString(w, "\n")
+		writeRSTBlocks(w, blocks, indent+"  ")
+	}
+}
+
+// writeIndented writes the lines of text with the indentation, keeping
+// the blank ones empty
+func writeIndented(w io.Writer, text, indent string) {
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			io.WriteString(w, "\n")
+		} else {
+			io.WriteString(w, indent+line+"\n")
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package render
+
+import (
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rcmachado/changelog/chg"
+)
+
+// Text renders the changelog as plain text for terminals, without the
+// markdown markup and with the items wrapped. With Color, ANSI escape
+// codes highlight the versions, change types and yanked releases.
+type Text struct {
+	Width int // Columns to wrap the text, 80 when zero
+	Color bool
+}
+
+// ANSI escape codes
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiItalic = "\x1b[3m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiBlue   = "\x1b[34m"
+	ansiPurple = "\x1b[35m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// changeTypeColors are the colors of the standard change types; other
+// types are bold
+var changeTypeColors = map[chg.ChangeType]string{
+	chg.Added:      ansiGreen,
+	chg.Changed:    ansiYellow,
+	chg.Deprecated: ansiPurple,
+	chg.Fixed:      ansiBlue,
+	chg.Removed:    ansiRed,
+	chg.Security:   ansiRed + ansiBold,
+}
+
+var reANSI = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+type textSyntax struct {
+	color bool
+}
+
+func (textSyntax) text(s string) string {
+	return s
+}
+
+func (t textSyntax) code(s string) string {
+	return t.style(ansiCyan, s)
+}
+
+func (t textSyntax) emph(s string) string {
+	return t.style(ansiItalic, s)
+}
+
+func (t textSyntax) strong(s string) string {
+	return t.style(ansiBold, s)
+}
+
+func (t textSyntax) link(text, url string) string {
+	if reANSI.ReplaceAllString(text, "") == url {
+		return text
+	}
+	return text + " (" + url + ")"
+}
+
+// style wraps s in the escape code when colors are enabled
+func (t textSyntax) style(code, s string) string {
+	if !t.color || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+func (t Text) syntax() textSyntax {
+	return textSyntax{color: t.Color}
+}
+
+func (t Text) width() int {
+	if t.Width <= 0 {
+		return 80
+	}
+	return t.Width
+}
+
+// Changelog writes the whole changelog
+func (t Text) Changelog(w io.Writer, c *chg.Changelog) error {
+	syntax := t.syntax()
+	t.heading(w, syntax.strong(changelogTitle(c)), "=")
+	if c.Preamble != "" {
+		io.WriteString(w, "\n")
+		writeTextBlocks(w, convertMarkdown(c.Preamble, c, syntax), "", t.width())
+	}
+	if len(c.Versions) > 0 {
+		io.WriteString(w, "\n")
+	}
+	return t.Versions(w, c, c.Versions)
+}
+
+// Version writes the version
+func (t Text) Version(w io.Writer, c *chg.Changelog, v *chg.Version) error {
+	return t.Versions(w, c, []*chg.Version{v})
+}
+
+// Versions writes the versions with their changes, the items wrapped
+// and indented under the change types
+func (t Text) Versions(w io.Writer, c *chg.Changelog, versions []*chg.Version) error {
+	syntax := t.syntax()
+	for idx, v := range versions {
+		if idx > 0 {
+			io.WriteString(w, "\n")
+		}
+		v.SortChanges()
+
+		title := v.Name
+		if v.Date != "" {
+			title += " - " + chg.NormalizeDate(v.Date)
+		}
+		title = syntax.strong(title)
+		if v.Yanked {
+			title += " " + syntax.style(ansiRed+ansiBold, "[YANKED]")
+		}
+		if v.Superseded {
+			title += " " + syntax.style(ansiDim, "[SUPERSEDED]")
+		}
+		t.heading(w, title, "-")
+
+		for _, change := range v.Changes {
+			color, ok := changeTypeColors[change.Type]
+			if !ok {
+				color = ansiBold
+			}
+			io.WriteString(w, "\n"+syntax.style(color, change.Type.String()+":")+"\n")
+
+			var items [][]*block
+			for _, item := range change.Items {
+				items = append(items, convertItem(item, c, syntax))
+			}
+			writeTextList(w, items, "  ", t.width())
+		}
+	}
+	return nil
+}
+
+// heading writes the heading, underlined with char when colors are
+// disabled
+func (t Text) heading(w io.Writer, text, char string) {
+	io.WriteString(w, text+"\n")
+	if !t.Color {
+		io.WriteString(w, strings.Repeat(char, visibleLen(text))+"\n")
+	}
+}
+
+// writeTextBlocks writes the blocks separated by blank lines, with the
+// paragraphs wrapped to width
+func writeTextBlocks(w io.Writer, blocks []*block, indent string, width int) {
+	for idx, b := range blocks {
+		if idx > 0 {
+			io.WriteString(w, "\n")
+		}
+
+		switch b.kind {
+		case paragraphBlock, headingBlock:
+			writeIndented(w, wrapText(b.text, width-len(indent)), indent)
+		case codeBlock:
+			writeIndented(w, b.text, indent+"    ")
+		case quoteBlock:
+			writeTextBlocks(w, b.children, indent+"| ", width)
+		case listBlock:
+			writeTextList(w, b.items, indent, width)
+		}
+	}
+}
+
+// writeTextList writes the items with "- " bullets and their content
+// aligned to the text
+func writeTextList(w io.Writer, items [][]*block, indent string, width int) {
+	for _, blocks := range items {
+		var buf strings.Builder
+		writeTextBlocks(&buf, blocks, "", width-len(indent)-2)
+
+		for idx, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+			switch {
+			case idx == 0:
+				io.WriteString(w, indent+"- "+line+"\n")
+			case line == "":
+				io.WriteString(w, "\n")
+			default:
+				io.WriteString(w, indent+"  "+line+"\n")
+			}
+		}
+	}
+}
+
+// wrapText breaks the text in lines of up to width visible characters.
+// Words longer than width are kept whole.
+func wrapText(text string, width int) string {
+	var buf strings.Builder
+	lineLen := 0
+	for _, word := range strings.Fields(text) {
+		wordLen := visibleLen(word)
+		switch {
+		case lineLen == 0:
+		case lineLen+1+wordLen > width:
+			buf.WriteString("\n")
+			lineLen = 0
+		default:
+			buf.WriteString(" ")
+			lineLen++
+		}
+		buf.WriteString(word)
+		lineLen += wordLen
+	}
+	return buf.String()
+}
+
+// visibleLen returns the number of characters of s, ignoring the ANSI
+// escape codes
+func visibleLen(s string) int {
+	return utf8.RuneCountInString(reANSI.ReplaceAllString(s, ""))
+}
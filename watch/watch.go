@@ -0,0 +1,169 @@
+// Package watch rebuilds the outputs generated from a changelog
+// whenever it changes
+package watch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rcmachado/changelog/changelog"
+	"github.com/rcmachado/changelog/lint"
+	"github.com/rcmachado/changelog/render"
+)
+
+// DefaultDebounce is how long to wait for more writes before rebuilding
+const DefaultDebounce = 200 * time.Millisecond
+
+// Output is a file generated from the changelog
+type Output struct {
+	Format string // One of render.Formats()
+	Path   string
+}
+
+// Watcher monitors the changelog and rebuilds the outputs
+type Watcher struct {
+	Filename string   // Changelog file
+	Paths    []string // Other files or directories that trigger a rebuild
+	Outputs  []Output
+	Debounce time.Duration
+	Log      io.Writer // Receives the lint problems and the files written
+}
+
+// Build parses the changelog, prints its lint problems and writes the
+// outputs. Outputs are only written when their content changes.
+func (w *Watcher) Build() error {
+	doc, err := changelog.Load(context.Background(), w.Filename)
+	if err != nil {
+		return err
+	}
+
+	problems := lint.Check(doc.Changelog)
+	for _, p := range problems {
+		w.logf("%s: %s\n", w.Filename, p)
+	}
+
+	for _, out := range w.Outputs {
+		r, err := render.New(out.Format)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := r.Changelog(&buf, doc.Changelog); err != nil {
+			return err
+		}
+
+		if current, err := ioutil.ReadFile(out.Path); err == nil && bytes.Equal(current, buf.Bytes()) {
+			continue
+		}
+		if err := ioutil.WriteFile(out.Path, buf.Bytes(), 0644); err != nil {
+			return err
+		}
+		w.logf("Wrote %s\n", out.Path)
+	}
+
+	if len(problems) == 0 {
+		w.logf("%s: no problems found\n", w.Filename)
+	}
+	w.flush()
+	return nil
+}
+
+// Run builds the outputs and rebuilds them on each change until stop
+// is closed. Bursts of writes, like the ones done by editors when
+// saving, trigger a single rebuild. Build errors are logged and don't
+// stop the watcher.
+func (w *Watcher) Run(stop <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	// Editors often replace the file instead of writing to it, so the
+	// parent directories are watched and the events filtered
+	files := make(map[string]bool)
+	dirs := make(map[string]bool)
+	for _, path := range append([]string{w.Filename}, w.Paths...) {
+		path = filepath.Clean(path)
+
+		dir := filepath.Dir(path)
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			dir = path
+			dirs[path] = true
+		} else {
+			files[path] = true
+		}
+
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("Failed to watch '%s': %s", dir, err)
+		}
+	}
+
+	outputs := make(map[string]bool)
+	for _, out := range w.Outputs {
+		outputs[filepath.Clean(out.Path)] = true
+	}
+
+	w.rebuild()
+
+	debounce := w.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			name := filepath.Clean(event.Name)
+			if outputs[name] || event.Op == fsnotify.Chmod {
+				continue
+			}
+			if files[name] || dirs[filepath.Dir(name)] {
+				timer.Reset(debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.logf("error: %s\n", err)
+			w.flush()
+		case <-timer.C:
+			w.rebuild()
+		}
+	}
+}
+
+func (w *Watcher) rebuild() {
+	if err := w.Build(); err != nil {
+		w.logf("error: %s\n", err)
+		w.flush()
+	}
+}
+
+func (w *Watcher) logf(format string, args ...interface{}) {
+	if w.Log != nil {
+		fmt.Fprintf(w.Log, format, args...)
+	}
+}
+
+// flush writes the buffered log, as the watcher runs until interrupted
+func (w *Watcher) flush() {
+	if f, ok := w.Log.(interface{ Flush() error }); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,168 @@
+package watch
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testChangelog = `# Changelog
+
+## [Unreleased]
+### Added
+- Item 1
+
+[Unreleased]: https://example.com/compare/1.0.0...HEAD
+`
+
+// syncBuffer is a buffer safe to be written by the watcher goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func newTestWatcher(t *testing.T) (*Watcher, string) {
+	dir, err := ioutil.TempDir("", "changelog-watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(dir, "CHANGELOG.md")
+	if err := ioutil.WriteFile(filename, []byte(testChangelog), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return &Watcher{
+		Filename: filename,
+		Outputs: []Output{
+			{Format: "json", Path: filepath.Join(dir, "changelog.json")},
+			{Format: "html", Path: filepath.Join(dir, "changelog.html")},
+		},
+		Debounce: 50 * time.Millisecond,
+		Log:      &syncBuffer{},
+	}, dir
+}
+
+// waitFor polls cond until it's true or the timeout expires
+func waitFor(t *testing.T, cond func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	content, _ := ioutil.ReadFile(path)
+	return string(content)
+}
+
+func TestBuild(t *testing.T) {
+	w, dir := newTestWatcher(t)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, w.Build())
+	assert.Contains(t, readFile(t, filepath.Join(dir, "changelog.json")), `"Item 1"`)
+	assert.Contains(t, readFile(t, filepath.Join(dir, "changelog.html")), "<li>Item 1</li>")
+
+	expected := "Wrote " + filepath.Join(dir, "changelog.json") + "\n" +
+		"Wrote " + filepath.Join(dir, "changelog.html") + "\n" +
+		w.Filename + ": no problems found\n"
+	assert.Equal(t, expected, w.Log.(*syncBuffer).String())
+
+	// Unchanged outputs aren't written again
+	w.Log = &syncBuffer{}
+	assert.Nil(t, w.Build())
+	assert.Equal(t, w.Filename+": no problems found\n", w.Log.(*syncBuffer).String())
+}
+
+func TestBuildLint(t *testing.T) {
+	w, dir := newTestWatcher(t)
+	defer os.RemoveAll(dir)
+	w.Outputs = nil
+
+	ioutil.WriteFile(w.Filename, []byte("# Changelog\n\n## 1.0.0\n### Added\n- Item\n"), 0644)
+
+	assert.Nil(t, w.Build())
+	assert.Equal(t, w.Filename+": missing Unreleased version\n"+w.Filename+": 1.0.0: missing release date\n", w.Log.(*syncBuffer).String())
+}
+
+func TestBuildUnknownFormat(t *testing.T) {
+	w, dir := newTestWatcher(t)
+	defer os.RemoveAll(dir)
+	w.Outputs = []Output{{Format: "pdf", Path: filepath.Join(dir, "changelog.pdf")}}
+
+	assert.EqualError(t, w.Build(), "Unknown format: 'pdf'")
+}
+
+func TestBuildInvalid(t *testing.T) {
+	w, dir := newTestWatcher(t)
+	defer os.RemoveAll(dir)
+
+	ioutil.WriteFile(w.Filename, []byte("# Changelog\n\n## [\n"), 0644)
+
+	assert.EqualError(t, w.Build(), "reading changelog: line 3: invalid version heading '['")
+	assert.NoFileExists(t, filepath.Join(dir, "changelog.json"))
+}
+
+func TestRun(t *testing.T) {
+	w, dir := newTestWatcher(t)
+	defer os.RemoveAll(dir)
+
+	fragments := filepath.Join(dir, "fragments")
+	os.Mkdir(fragments, 0755)
+	w.Paths = []string{fragments}
+
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- w.Run(stop)
+	}()
+
+	output := filepath.Join(dir, "changelog.json")
+	waitFor(t, func() bool { return strings.Contains(readFile(t, output), "Item 1") })
+
+	// A burst of writes is rebuilt once
+	for _, item := range []string{"Item 2", "Item 3", "Item 4"} {
+		content := strings.Replace(testChangelog, "- Item 1\n", "- Item 1\n- "+item+"\n", 1)
+		ioutil.WriteFile(w.Filename, []byte(content), 0644)
+	}
+	waitFor(t, func() bool { return strings.Contains(readFile(t, output), "Item 4") })
+
+	time.Sleep(200 * time.Millisecond)
+	log := w.Log.(*syncBuffer).String()
+	assert.Equal(t, 2, strings.Count(log, "Wrote "+output))
+
+	// Changes in the other paths trigger a rebuild too
+	ioutil.WriteFile(w.Filename, []byte(testChangelog), 0644)
+	waitFor(t, func() bool { return !strings.Contains(readFile(t, output), "Item 4") })
+	count := strings.Count(w.Log.(*syncBuffer).String(), "no problems found")
+	ioutil.WriteFile(filepath.Join(fragments, "1.md"), []byte("- Item\n"), 0644)
+	waitFor(t, func() bool {
+		return strings.Count(w.Log.(*syncBuffer).String(), "no problems found") > count
+	})
+
+	close(stop)
+	assert.Nil(t, <-done)
+}
@@ -0,0 +1,117 @@
+package workspace
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Package is a changelog managed as part of the workspace
+type Package struct {
+	Name      string
+	Path      string // Path to the changelog file
+	TagPrefix string // Prefix of the tags referenced by compare links
+}
+
+// Result holds the outcome of running an action for a package
+type Result struct {
+	Package Package
+	Output  string
+	Err     error
+}
+
+// Action is executed for each package
+type Action func(pkg Package) (string, error)
+
+// Discover finds the changelog files matching the glob patterns.
+// The package name is the directory containing the file.
+// tagPrefix may reference the package name using "{name}".
+func Discover(patterns []string, tagPrefix string) ([]Package, error) {
+	var pkgs []Package
+	seen := make(map[string]bool)
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %s", pattern, err)
+		}
+		sort.Strings(matches)
+
+		for _, path := range matches {
+			path = filepath.Clean(path)
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+
+			name := filepath.Base(filepath.Dir(path))
+			if abs, err := filepath.Abs(path); err == nil {
+				name = filepath.Base(filepath.Dir(abs))
+			}
+			pkgs = append(pkgs, Package{
+				Name:      name,
+				Path:      path,
+				TagPrefix: ExpandTagPrefix(tagPrefix, name),
+			})
+		}
+	}
+
+	return pkgs, nil
+}
+
+// Unique removes the packages pointing to a changelog file already in
+// pkgs, keeping the first one. Paths are compared after being made
+// absolute, so the same file isn't changed twice at the same time.
+func Unique(pkgs []Package) []Package {
+	var result []Package
+	seen := make(map[string]bool)
+
+	for _, pkg := range pkgs {
+		path := filepath.Clean(pkg.Path)
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		result = append(result, pkg)
+	}
+
+	return result
+}
+
+// ExpandTagPrefix replaces "{name}" with the package name
+func ExpandTagPrefix(tagPrefix, name string) string {
+	return strings.Replace(tagPrefix, "{name}", name, -1)
+}
+
+// Run executes action for every package using up to concurrency
+// goroutines. Results are returned in the same order as pkgs.
+func Run(pkgs []Package, concurrency int, action Action) []Result {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]Result, len(pkgs))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for idx, pkg := range pkgs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(idx int, pkg Package) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			output, err := action(pkg)
+			results[idx] = Result{Package: pkg, Output: output, Err: err}
+		}(idx, pkg)
+	}
+	wg.Wait()
+
+	return results
+}
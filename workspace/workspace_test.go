@@ -0,0 +1,61 @@
+package workspace
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscover(t *testing.T) {
+	pkgs, err := Discover([]string{"testdata/packages/*/CHANGELOG.md", "testdata/packages/a/*.md"}, "{name}@")
+	assert.NoError(t, err)
+
+	expected := []Package{
+		{Name: "a", Path: "testdata/packages/a/CHANGELOG.md", TagPrefix: "a@"},
+		{Name: "b", Path: "testdata/packages/b/CHANGELOG.md", TagPrefix: "b@"},
+	}
+	assert.Equal(t, expected, pkgs)
+}
+
+func TestDiscoverInvalidPattern(t *testing.T) {
+	_, err := Discover([]string{"testdata/["}, "")
+	assert.Error(t, err)
+}
+
+func TestUnique(t *testing.T) {
+	abs, err := filepath.Abs("testdata/packages/a/CHANGELOG.md")
+	assert.NoError(t, err)
+
+	pkgs := []Package{
+		{Name: "pkg-a", Path: "testdata/packages/a/CHANGELOG.md"},
+		{Name: "a", Path: "./testdata/packages/b/../a/CHANGELOG.md"},
+		{Name: "b", Path: "testdata/packages/b/CHANGELOG.md"},
+		{Name: "a", Path: abs},
+	}
+
+	expected := []Package{pkgs[0], pkgs[2]}
+	assert.Equal(t, expected, Unique(pkgs))
+}
+
+func TestRun(t *testing.T) {
+	pkgs := []Package{{Name: "slow"}, {Name: "fast"}, {Name: "broken"}}
+
+	results := Run(pkgs, 3, func(pkg Package) (string, error) {
+		switch pkg.Name {
+		case "slow":
+			time.Sleep(10 * time.Millisecond)
+		case "broken":
+			return "", errors.New("failed")
+		}
+		return pkg.Name, nil
+	})
+
+	assert.Len(t, results, 3)
+	assert.Equal(t, "slow", results[0].Output)
+	assert.Equal(t, "fast", results[1].Output)
+	assert.Error(t, results[2].Err)
+	assert.Equal(t, pkgs[2], results[2].Package)
+}